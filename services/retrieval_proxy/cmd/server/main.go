@@ -4,36 +4,46 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
 
 	"github.com/searchforge/retrieval_proxy/fuse"
 	"github.com/searchforge/retrieval_proxy/internal/api"
+	"github.com/searchforge/retrieval_proxy/internal/cache"
 	"github.com/searchforge/retrieval_proxy/internal/controller"
 	"github.com/searchforge/retrieval_proxy/internal/health"
+	"github.com/searchforge/retrieval_proxy/internal/rewriter"
+	tlsserver "github.com/searchforge/retrieval_proxy/internal/server"
 	"github.com/searchforge/retrieval_proxy/obs"
 	"github.com/searchforge/retrieval_proxy/policy"
 	"github.com/searchforge/retrieval_proxy/sources"
 )
 
 const (
-	defaultPort         = 7070
-	defaultBudgetMS     = 600
-	defaultTimeoutMS    = 800
-	defaultTopK         = 10
-	defaultTopKMax      = 64
-	defaultTopKInit     = 32
-	defaultRetryMax     = 2
-	defaultCacheTTLMS   = 0
-	defaultLangfuseHost = "https://us.cloud.langfuse.com"
+	defaultPort                = 7070
+	defaultBudgetMS            = 600
+	defaultTimeoutMS           = 800
+	defaultTopK                = 10
+	defaultTopKMax             = 64
+	defaultTopKInit            = 32
+	defaultRetryMax            = 2
+	defaultHedgeMax            = 1
+	defaultCacheTTLMS          = 0
+	defaultCacheNegativeTTLMS  = 2000
+	defaultCacheCapacity       = 10000
+	defaultLangfuseHost        = "https://us.cloud.langfuse.com"
+	defaultDebugSearchCapacity = 10000
 )
 
 func main() {
@@ -52,34 +62,32 @@ func main() {
 	}()
 
 	client := newHTTPClient(cfg.Timeout)
-	qdrant, err := sources.NewQdrantSource(cfg.QdrantURL, client, cfg.RetryMax)
+	sourceBindings, err := loadSourceBindings(cfg, client)
 	if err != nil {
-		log.Fatalf("qdrant init: %v", err)
-	}
-
-	ctrl, err := controller.New(qdrant, controller.Config{
-		SourceName: cfg.SourceName,
-		Collection: cfg.QdrantCollection,
-		Policy: policy.SourceConfig{
-			Name: cfg.SourceName,
-			Timeout: cfg.Timeout,
-			Rate: policy.RateLimitConfig{
-				Capacity:     cfg.RateCapacity,
-				RefillTokens: cfg.RateRefill,
-				RefillEvery:  cfg.RateInterval,
-			},
-			Circuit: policy.CircuitConfig{
-				FailureThreshold:  cfg.FailureThreshold,
-				HalfOpenSuccesses: cfg.HalfOpenSuccesses,
-				Cooldown:          cfg.CircuitCooldown,
-			},
-		},
+		log.Fatalf("sources init: %v", err)
+	}
+
+	rewriterPipeline, err := loadRewriterPipeline(cfg)
+	if err != nil {
+		log.Fatalf("rewriter init: %v", err)
+	}
+
+	ctrl, err := controller.New(controller.Config{
+		Sources:  sourceBindings,
+		Rewriter: rewriterPipeline,
 		Fuse: fuse.CombineConfig{
-			RRFK:     cfg.RRFK,
-			TopKInit: cfg.TopKInit,
-			TopKMax:  cfg.TopKMax,
+			RRFK:               cfg.RRFK,
+			TopKInit:           cfg.TopKInit,
+			TopKMax:            cfg.TopKMax,
+			TraceContributions: cfg.TraceFuseContributions,
+		},
+		Cache: cache.Config{
+			TTL:         cfg.CacheTTL,
+			NegativeTTL: cfg.CacheNegativeTTL,
+			Capacity:    cfg.CacheCapacity,
+			RedisAddr:   cfg.RedisAddr,
+			RedisPrefix: "retrieval_proxy:",
 		},
-		CacheTTL:        cfg.CacheTTL,
 		PolicyVersion:   cfg.PolicyVersion,
 		LangfuseHost:    cfg.LangfuseHost,
 		LangfuseProject: cfg.LangfuseProject,
@@ -93,11 +101,36 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("ok"))
 	})
-	root.Get("/readyz", health.Readyz(ctrl))
+	root.Get("/readyz", health.Readyz(health.RegisteredCheck{
+		Checker:  health.NewQdrantChecker(ctrl, 200*time.Millisecond),
+		Required: true,
+		Timeout:  200 * time.Millisecond,
+	}))
+	root.Get("/livez", health.Livez(50*time.Millisecond))
 	root.Handle("/metrics", promhttp.Handler())
 
-	apiRouter := api.NewRouter(ctrl, cfg.DefaultK, cfg.BudgetMS, cfg.TopKMax)
-	root.Mount("/", apiRouter)
+	if cfg.TenantConfigPath != "" {
+		policyCtrl := ctrl.PolicyController()
+		if err := policyCtrl.LoadInitial(cfg.TenantConfigPath); err != nil {
+			log.Printf("tenant config init: %v", err)
+		}
+		root.Post("/admin/reload", policyCtrl.ReloadHandler(cfg.TenantConfigPath))
+
+		reloadCtx, cancelReload := context.WithCancel(context.Background())
+		defer cancelReload()
+		policyCtrl.WatchReloadSignal(reloadCtx, cfg.TenantConfigPath)
+	}
+
+	searchIndex := api.NewSearchIndex(cfg.DebugSearchCapacity, nil)
+	root.Handle("/debug/searches", api.NewDebugHandler(searchIndex, ctrl.BuildTraceURL))
+	root.Handle("/debug/searches/*", api.NewDebugHandler(searchIndex, ctrl.BuildTraceURL))
+
+	apiHandler := api.NewHandler(ctrl, cfg.DefaultK, cfg.BudgetMS, cfg.TopKMax,
+		api.WithSearchIndex(searchIndex),
+		api.WithPolicyVersion(cfg.PolicyVersion),
+		api.WithTracerProvider(otel.GetTracerProvider()),
+	)
+	root.Mount("/", apiHandler)
 
 	server := &http.Server{
 		Addr:         ":" + strconv.Itoa(cfg.Port),
@@ -108,6 +141,13 @@ func main() {
 	}
 
 	go func() {
+		if cfg.TLS.CertFile != "" {
+			log.Printf("retrieval proxy listening on :%d (tls)", cfg.Port)
+			if err := tlsserver.ListenAndServeTLS(server.Addr, root, cfg.TLS); err != nil && err != http.ErrServerClosed {
+				log.Fatalf("listen error: %v", err)
+			}
+			return
+		}
 		log.Printf("retrieval proxy listening on :%d", cfg.Port)
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("listen error: %v", err)
@@ -126,53 +166,179 @@ func main() {
 }
 
 type config struct {
-	Port              int
-	BudgetMS          int
-	DefaultK          int
-	TopKMax           int
-	TopKInit          int
-	RRFK              int
-	QdrantURL         string
-	QdrantCollection  string
-	SourceName        string
-	Timeout           time.Duration
-	RetryMax          int
-	RateCapacity      int
-	RateRefill        int
-	RateInterval      time.Duration
-	FailureThreshold  int
-	HalfOpenSuccesses int
-	CircuitCooldown   time.Duration
-	CacheTTL          time.Duration
-	PolicyVersion     string
-	LangfuseHost      string
-	LangfuseProject   string
+	Port                    int
+	BudgetMS                int
+	DefaultK                int
+	TopKMax                 int
+	TopKInit                int
+	RRFK                    int
+	QdrantURL               string
+	QdrantCollection        string
+	SourceName              string
+	Timeout                 time.Duration
+	RetryMax                int
+	HedgeAfter              time.Duration
+	HedgeMax                int
+	RateCapacity            int
+	RateRefill              int
+	RateInterval            time.Duration
+	CircuitWindow           time.Duration
+	CircuitFailureRate      float64
+	CircuitMinSamples       int
+	CircuitCooldown         time.Duration
+	CircuitHalfOpenMaxCalls int
+	CacheTTL                time.Duration
+	CacheNegativeTTL        time.Duration
+	CacheCapacity           int
+	RedisAddr               string
+	TraceFuseContributions  bool
+	RewriterConfigPath      string
+	RewriterLLMEndpoint     string
+	RewriterLLMTimeout      time.Duration
+	TenantConfigPath        string
+	PolicyVersion           string
+	LangfuseHost            string
+	LangfuseProject         string
+	TLS                     tlsserver.TLSConfig
+	DebugSearchCapacity     int
+	SourcesConfigPath       string
 }
 
 func loadConfig() config {
 	cacheTTL := time.Duration(getEnvInt("CACHE_TTL_MS", defaultCacheTTLMS)) * time.Millisecond
 	return config{
-		Port:              getEnvInt("PORT", defaultPort),
-		BudgetMS:          getEnvInt("BUDGET_MS", defaultBudgetMS),
-		DefaultK:          getEnvInt("DEFAULT_K", defaultTopK),
-		TopKMax:           getEnvInt("TOPK_MAX", defaultTopKMax),
-		TopKInit:          getEnvInt("TOPK_INIT", defaultTopKInit),
-		RRFK:              getEnvInt("RRF_K", fuse.DefaultCombineConfig().RRFK),
-		QdrantURL:         getEnvStr("QDRANT_URL", "http://qdrant:6333"),
-		QdrantCollection:  getEnvStr("QDRANT_COLLECTION", ""),
-		SourceName:        getEnvStr("SOURCE_NAME", "qdrant"),
-		Timeout:           time.Duration(getEnvInt("TIMEOUT_MS", defaultTimeoutMS)) * time.Millisecond,
-		RetryMax:          getEnvInt("RETRY_MAX", defaultRetryMax),
-		RateCapacity:      getEnvInt("SOURCE_RATE_CAPACITY", 50),
-		RateRefill:        getEnvInt("SOURCE_RATE_REFILL", 10),
-		RateInterval:      time.Duration(getEnvInt("SOURCE_RATE_INTERVAL_MS", 1000)) * time.Millisecond,
-		FailureThreshold:  getEnvInt("CIRCUIT_FAILURES", 3),
-		HalfOpenSuccesses: getEnvInt("CIRCUIT_HALF_OPEN_SUCCESS", 1),
-		CircuitCooldown:   time.Duration(getEnvInt("CIRCUIT_COOLDOWN_MS", 2000)) * time.Millisecond,
-		CacheTTL:          cacheTTL,
-		PolicyVersion:     getEnvStr("POLICY_VERSION", "v1"),
-		LangfuseHost:      getEnvStr("LANGFUSE_HOST", defaultLangfuseHost),
-		LangfuseProject:   getEnvStr("LANGFUSE_PROJECT_ID", ""),
+		Port:                    getEnvInt("PORT", defaultPort),
+		BudgetMS:                getEnvInt("BUDGET_MS", defaultBudgetMS),
+		DefaultK:                getEnvInt("DEFAULT_K", defaultTopK),
+		TopKMax:                 getEnvInt("TOPK_MAX", defaultTopKMax),
+		TopKInit:                getEnvInt("TOPK_INIT", defaultTopKInit),
+		RRFK:                    getEnvInt("RRF_K", fuse.DefaultCombineConfig().RRFK),
+		QdrantURL:               getEnvStr("QDRANT_URL", "http://qdrant:6333"),
+		QdrantCollection:        getEnvStr("QDRANT_COLLECTION", ""),
+		SourceName:              getEnvStr("SOURCE_NAME", "qdrant"),
+		Timeout:                 time.Duration(getEnvInt("TIMEOUT_MS", defaultTimeoutMS)) * time.Millisecond,
+		RetryMax:                getEnvInt("RETRY_MAX", defaultRetryMax),
+		HedgeAfter:              time.Duration(getEnvInt("HEDGE_AFTER_MS", 0)) * time.Millisecond,
+		HedgeMax:                getEnvInt("HEDGE_MAX", defaultHedgeMax),
+		RateCapacity:            getEnvInt("SOURCE_RATE_CAPACITY", 50),
+		RateRefill:              getEnvInt("SOURCE_RATE_REFILL", 10),
+		RateInterval:            time.Duration(getEnvInt("SOURCE_RATE_INTERVAL_MS", 1000)) * time.Millisecond,
+		CircuitWindow:           time.Duration(getEnvInt("CIRCUIT_WINDOW_MS", 10000)) * time.Millisecond,
+		CircuitFailureRate:      getEnvFloat("CIRCUIT_FAILURE_RATE", 0.5),
+		CircuitMinSamples:       getEnvInt("CIRCUIT_MIN_SAMPLES", 3),
+		CircuitCooldown:         time.Duration(getEnvInt("CIRCUIT_COOLDOWN_MS", 2000)) * time.Millisecond,
+		CircuitHalfOpenMaxCalls: getEnvInt("CIRCUIT_HALF_OPEN_MAX_CALLS", 1),
+		CacheTTL:                cacheTTL,
+		CacheNegativeTTL:        time.Duration(getEnvInt("CACHE_NEGATIVE_TTL_MS", defaultCacheNegativeTTLMS)) * time.Millisecond,
+		CacheCapacity:           getEnvInt("CACHE_CAPACITY", defaultCacheCapacity),
+		RedisAddr:               getEnvStr("REDIS_ADDR", ""),
+		TraceFuseContributions:  getEnvStr("TRACE_FUSE_CONTRIBUTIONS", "") == "true",
+		RewriterConfigPath:      getEnvStr("REWRITER_CONFIG", ""),
+		RewriterLLMEndpoint:     getEnvStr("REWRITER_LLM_ENDPOINT", ""),
+		RewriterLLMTimeout:      time.Duration(getEnvInt("REWRITER_LLM_TIMEOUT_MS", 2000)) * time.Millisecond,
+		TenantConfigPath:        getEnvStr("TENANT_CONFIG", ""),
+		PolicyVersion:           getEnvStr("POLICY_VERSION", "v1"),
+		LangfuseHost:            getEnvStr("LANGFUSE_HOST", defaultLangfuseHost),
+		LangfuseProject:         getEnvStr("LANGFUSE_PROJECT_ID", ""),
+		TLS:                     loadTLSConfig(),
+		DebugSearchCapacity:     getEnvInt("DEBUG_SEARCH_CAPACITY", defaultDebugSearchCapacity),
+		SourcesConfigPath:       getEnvStr("SOURCES_CONFIG", ""),
+	}
+}
+
+// loadSourceBindings builds the controller.SourceBinding list the proxy
+// fans queries out to. When cfg.SourcesConfigPath points at a
+// SOURCES_CONFIG file, every source it enumerates is registered; otherwise
+// a single Qdrant source is built from the legacy QDRANT_* env vars, to
+// keep default behaviour unchanged for deployments that don't opt in.
+func loadSourceBindings(cfg config, client sources.HTTPClient) ([]controller.SourceBinding, error) {
+	if cfg.SourcesConfigPath == "" {
+		qdrant, err := sources.NewQdrantSource(cfg.QdrantURL, client, cfg.RetryMax, cfg.HedgeAfter, cfg.HedgeMax)
+		if err != nil {
+			return nil, fmt.Errorf("qdrant init: %w", err)
+		}
+		return []controller.SourceBinding{
+			{
+				Name:       cfg.SourceName,
+				Collection: cfg.QdrantCollection,
+				Plugin:     sources.NewQdrantPlugin(qdrant, cfg.QdrantCollection),
+				Policy:     defaultSourcePolicy(cfg),
+			},
+		}, nil
+	}
+
+	specs, err := sources.LoadSourcesConfig(cfg.SourcesConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([]controller.SourceBinding, 0, len(specs))
+	for _, spec := range specs {
+		plugin, err := spec.BuildPlugin(client)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", spec.Name, err)
+		}
+		policyConfig := spec.Policy
+		policyConfig.Name = spec.Name
+		bindings = append(bindings, controller.SourceBinding{
+			Name:       spec.Name,
+			Collection: spec.Collection,
+			Plugin:     plugin,
+			Policy:     policyConfig,
+		})
+	}
+	return bindings, nil
+}
+
+func loadRewriterPipeline(cfg config) (*rewriter.Pipeline, error) {
+	rewriterCfg := rewriter.Config{}
+	if cfg.RewriterConfigPath != "" {
+		var err error
+		rewriterCfg, err = rewriter.LoadConfig(cfg.RewriterConfigPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var llm rewriter.LLMClient
+	if cfg.RewriterLLMEndpoint != "" {
+		llm = rewriter.NewHTTPLLMClient(cfg.RewriterLLMEndpoint, cfg.RewriterLLMTimeout)
+	}
+	return rewriter.New(rewriterCfg, llm), nil
+}
+
+func defaultSourcePolicy(cfg config) policy.SourceConfig {
+	return policy.SourceConfig{
+		Name:    cfg.SourceName,
+		Timeout: cfg.Timeout,
+		Rate: policy.RateLimitConfig{
+			Capacity:     cfg.RateCapacity,
+			RefillTokens: cfg.RateRefill,
+			RefillEvery:  cfg.RateInterval,
+		},
+		Circuit: policy.CircuitBreakerConfig{
+			Window:               cfg.CircuitWindow,
+			FailureRateThreshold: cfg.CircuitFailureRate,
+			MinSamples:           cfg.CircuitMinSamples,
+			Cooldown:             cfg.CircuitCooldown,
+			HalfOpenMaxCalls:     cfg.CircuitHalfOpenMaxCalls,
+		},
+	}
+}
+
+func loadTLSConfig() tlsserver.TLSConfig {
+	var suites []string
+	if raw := getEnvStr("TLS_CIPHER_SUITES", ""); raw != "" {
+		suites = strings.Split(raw, ",")
+	}
+	return tlsserver.TLSConfig{
+		MinVersion:           getEnvStr("TLS_MIN_VERSION", "1.2"),
+		CipherSuites:         suites,
+		CertFile:             getEnvStr("TLS_CERT_FILE", ""),
+		KeyFile:              getEnvStr("TLS_KEY_FILE", ""),
+		ClientCAFile:         getEnvStr("TLS_CLIENT_CA_FILE", ""),
+		ClientAuth:           getEnvStr("TLS_CLIENT_AUTH", ""),
+		AllowInsecureCiphers: getEnvStr("TLS_ALLOW_INSECURE_CIPHERS", "") == "true",
 	}
 }
 
@@ -208,3 +374,15 @@ func getEnvInt(key string, fallback int) int {
 	}
 	return parsed
 }
+
+func getEnvFloat(key string, fallback float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
@@ -2,7 +2,14 @@ package fuse
 
 // mvp-5
 
-import "sort"
+import (
+	"context"
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
 
 // Item represents a ranked item returned by an upstream source.
 type Item struct {
@@ -36,12 +43,68 @@ type FusedItem struct {
 	OriginalScores map[string]float64
 }
 
+// Weights holds per-source score multipliers used by WeightedCombine, e.g.
+// {"qdrant": 1.0, "bm25": 0.7}. A source absent from the map defaults to 1.0.
+type Weights map[string]float64
+
+func (w Weights) weightFor(source string) float64 {
+	if weight, ok := w[source]; ok {
+		return weight
+	}
+	return 1.0
+}
+
+// ScoreNormalization selects how WeightedCombine rescales each source's
+// RawScore before blending it with the rank-based RRF contribution.
+type ScoreNormalization int
+
+const (
+	// ScoreNormalizationNone leaves raw scores out of the blend entirely,
+	// so WeightedCombine degenerates to weighted RRF.
+	ScoreNormalizationNone ScoreNormalization = iota
+	// ScoreNormalizationMinMax rescales a source's scores to [0, 1] using
+	// that source's own min and max in the current result set.
+	ScoreNormalizationMinMax
+	// ScoreNormalizationZScore rescales a source's scores to standard
+	// deviations from that source's own mean in the current result set.
+	ScoreNormalizationZScore
+	// ScoreNormalizationSoftmaxTemperature rescales a source's scores with
+	// a temperature-scaled softmax, so they sum to 1 across that source's
+	// results.
+	ScoreNormalizationSoftmaxTemperature
+)
+
 // CombineConfig controls how RRF aggregation selects top results.
 type CombineConfig struct {
 	RRFK       int
 	TopKInit   int
 	TopKMax    int
 	ScoreFloor float64
+
+	// Weights applies a per-source multiplier to WeightedCombine's blended
+	// score. Ignored by RRFCombine.
+	Weights Weights
+
+	// Normalization selects how each source's RawScore is rescaled before
+	// blending with the rank-based RRF contribution in WeightedCombine.
+	// Ignored by RRFCombine.
+	Normalization ScoreNormalization
+
+	// Alpha blends the rank-based RRF score with the normalized raw score
+	// in WeightedCombine: 0 is pure RRF, 1 is pure normalized-score fusion.
+	// Ignored by RRFCombine and when Normalization is ScoreNormalizationNone.
+	Alpha float64
+
+	// SoftmaxTemperature controls how peaked
+	// ScoreNormalizationSoftmaxTemperature is; lower values sharpen the
+	// distribution. Defaults to 1 when unset.
+	SoftmaxTemperature float64
+
+	// TraceContributions, when set, adds a span event to the active span
+	// in the combine call's context for every source contribution to a
+	// fused item, so an operator can open one trace in Grafana/Tempo and
+	// see exactly which source contributed each result.
+	TraceContributions bool
 }
 
 // DefaultCombineConfig returns conservative defaults.
@@ -53,8 +116,7 @@ func DefaultCombineConfig() CombineConfig {
 	}
 }
 
-// RRFCombine merges source results with Reciprocal Rank Fusion and deduplication.
-func RRFCombine(results []SourceResult, cfg CombineConfig) []FusedItem {
+func fillCombineDefaults(cfg CombineConfig) CombineConfig {
 	if cfg.RRFK <= 0 {
 		cfg.RRFK = DefaultCombineConfig().RRFK
 	}
@@ -67,6 +129,16 @@ func RRFCombine(results []SourceResult, cfg CombineConfig) []FusedItem {
 	if cfg.TopKInit > cfg.TopKMax {
 		cfg.TopKInit = cfg.TopKMax
 	}
+	if cfg.SoftmaxTemperature <= 0 {
+		cfg.SoftmaxTemperature = 1.0
+	}
+	return cfg
+}
+
+// RRFCombine merges source results with Reciprocal Rank Fusion and deduplication.
+func RRFCombine(ctx context.Context, results []SourceResult, cfg CombineConfig) []FusedItem {
+	cfg = fillCombineDefaults(cfg)
+	span := contributionSpan(ctx, cfg)
 
 	type aggregate struct {
 		item FusedItem
@@ -78,6 +150,7 @@ func RRFCombine(results []SourceResult, cfg CombineConfig) []FusedItem {
 		for idx, it := range src.Items {
 			rank := idx + 1
 			score := 1.0 / float64(cfg.RRFK+rank)
+			recordContribution(span, src.Source, it.ID, rank, it.Score, score)
 
 			agg, exists := items[it.ID]
 			if !exists {
@@ -138,6 +211,257 @@ func RRFCombine(results []SourceResult, cfg CombineConfig) []FusedItem {
 	return fused[:limit]
 }
 
+// BlendDetail records one source's contribution to a WeightedCombine item:
+// its RRF rank score and normalized raw score, blended together with
+// Alpha and the source's Weight.
+type BlendDetail struct {
+	Source          string
+	Rank            int
+	RawScore        float64
+	NormalizedScore float64
+	RRFScore        float64
+	Weight          float64
+	Blended         float64
+}
+
+// CombineReport pairs WeightedCombine's fused items with the per-source
+// blend breakdown behind each one, so API consumers can debug why an item
+// ranked where it did.
+type CombineReport struct {
+	Items  []FusedItem
+	Blends map[string][]BlendDetail
+}
+
+// WeightedCombine merges source results like RRFCombine, but additionally
+// applies cfg.Weights per source and blends each item's rank-based RRF
+// score with a cfg.Normalization-rescaled raw score, weighted by cfg.Alpha.
+func WeightedCombine(ctx context.Context, results []SourceResult, cfg CombineConfig) CombineReport {
+	cfg = fillCombineDefaults(cfg)
+	span := contributionSpan(ctx, cfg)
+	normalized := normalizeScores(results, cfg.Normalization, cfg.SoftmaxTemperature)
+	alpha := cfg.Alpha
+	if cfg.Normalization == ScoreNormalizationNone {
+		alpha = 0
+	}
+
+	type aggregate struct {
+		item    FusedItem
+		details []BlendDetail
+	}
+
+	items := make(map[string]*aggregate)
+
+	for _, src := range results {
+		weight := cfg.Weights.weightFor(src.Source)
+		for idx, it := range src.Items {
+			rank := idx + 1
+			rrfScore := 1.0 / float64(cfg.RRFK+rank)
+			normScore := normalized[src.Source][it.ID]
+			blended := ((1-alpha)*rrfScore + alpha*normScore) * weight
+			recordContribution(span, src.Source, it.ID, rank, it.Score, blended)
+
+			agg, exists := items[it.ID]
+			if !exists {
+				agg = &aggregate{
+					item: FusedItem{
+						ID:            it.ID,
+						Payload:       it.Payload,
+						PrimarySource: src.Source,
+						FirstRank:     rank,
+						Contributions: []Contribution{},
+						OriginalScores: map[string]float64{
+							src.Source: it.Score,
+						},
+					},
+				}
+				items[it.ID] = agg
+			} else {
+				if agg.item.Payload == nil && it.Payload != nil {
+					agg.item.Payload = it.Payload
+				}
+				if _, ok := agg.item.OriginalScores[src.Source]; !ok {
+					agg.item.OriginalScores[src.Source] = it.Score
+				}
+			}
+
+			agg.item.Score += blended
+			agg.item.Contributions = append(agg.item.Contributions, Contribution{
+				Source:   src.Source,
+				Rank:     rank,
+				RawScore: it.Score,
+				Weight:   blended,
+			})
+			agg.details = append(agg.details, BlendDetail{
+				Source:          src.Source,
+				Rank:            rank,
+				RawScore:        it.Score,
+				NormalizedScore: normScore,
+				RRFScore:        rrfScore,
+				Weight:          weight,
+				Blended:         blended,
+			})
+		}
+	}
+
+	fused := make([]FusedItem, 0, len(items))
+	blends := make(map[string][]BlendDetail, len(items))
+	for id, agg := range items {
+		if cfg.ScoreFloor > 0 && agg.item.Score < cfg.ScoreFloor {
+			continue
+		}
+		fused = append(fused, agg.item)
+		blends[id] = agg.details
+	}
+
+	sortFused(fused)
+
+	limit := cfg.TopKInit
+	if limit > len(fused) {
+		limit = len(fused)
+	}
+	if limit > cfg.TopKMax {
+		limit = cfg.TopKMax
+	}
+	fused = fused[:limit]
+
+	return CombineReport{Items: fused, Blends: blends}
+}
+
+// normalizeScores computes, per source, a normalized version of each
+// item's RawScore according to mode. ScoreNormalizationNone leaves every
+// score at 0, so WeightedCombine degenerates to weighted RRF even if a
+// caller sets Alpha without also setting Normalization.
+func normalizeScores(results []SourceResult, mode ScoreNormalization, temperature float64) map[string]map[string]float64 {
+	out := make(map[string]map[string]float64, len(results))
+	for _, src := range results {
+		switch mode {
+		case ScoreNormalizationMinMax:
+			out[src.Source] = minMaxNormalize(src.Items)
+		case ScoreNormalizationZScore:
+			out[src.Source] = zScoreNormalize(src.Items)
+		case ScoreNormalizationSoftmaxTemperature:
+			out[src.Source] = softmaxNormalize(src.Items, temperature)
+		default:
+			scores := make(map[string]float64, len(src.Items))
+			for _, it := range src.Items {
+				scores[it.ID] = 0
+			}
+			out[src.Source] = scores
+		}
+	}
+	return out
+}
+
+func minMaxNormalize(items []Item) map[string]float64 {
+	scores := make(map[string]float64, len(items))
+	if len(items) == 0 {
+		return scores
+	}
+
+	minV, maxV := items[0].Score, items[0].Score
+	for _, it := range items[1:] {
+		if it.Score < minV {
+			minV = it.Score
+		}
+		if it.Score > maxV {
+			maxV = it.Score
+		}
+	}
+
+	for _, it := range items {
+		if maxV == minV {
+			scores[it.ID] = 0
+			continue
+		}
+		scores[it.ID] = (it.Score - minV) / (maxV - minV)
+	}
+	return scores
+}
+
+func zScoreNormalize(items []Item) map[string]float64 {
+	scores := make(map[string]float64, len(items))
+	if len(items) == 0 {
+		return scores
+	}
+
+	var sum float64
+	for _, it := range items {
+		sum += it.Score
+	}
+	mean := sum / float64(len(items))
+
+	var variance float64
+	for _, it := range items {
+		diff := it.Score - mean
+		variance += diff * diff
+	}
+	stddev := math.Sqrt(variance / float64(len(items)))
+
+	for _, it := range items {
+		if stddev == 0 {
+			scores[it.ID] = 0
+			continue
+		}
+		scores[it.ID] = (it.Score - mean) / stddev
+	}
+	return scores
+}
+
+func softmaxNormalize(items []Item, temperature float64) map[string]float64 {
+	scores := make(map[string]float64, len(items))
+	if len(items) == 0 {
+		return scores
+	}
+
+	maxScore := items[0].Score
+	for _, it := range items[1:] {
+		if it.Score > maxScore {
+			maxScore = it.Score
+		}
+	}
+
+	exps := make(map[string]float64, len(items))
+	var sumExp float64
+	for _, it := range items {
+		e := math.Exp((it.Score - maxScore) / temperature)
+		exps[it.ID] = e
+		sumExp += e
+	}
+	for id, e := range exps {
+		scores[id] = e / sumExp
+	}
+	return scores
+}
+
+// contributionSpan returns the span to attach contribution events to when
+// cfg.TraceContributions is set, or nil otherwise, so callers never pay for
+// oteltrace.SpanFromContext on the (default) untraced path.
+func contributionSpan(ctx context.Context, cfg CombineConfig) oteltrace.Span {
+	if !cfg.TraceContributions {
+		return nil
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return oteltrace.SpanFromContext(ctx)
+}
+
+// recordContribution adds a span event describing one source's
+// contribution to a fused item. span is nil when TraceContributions is
+// disabled, in which case this is a no-op.
+func recordContribution(span oteltrace.Span, source, itemID string, rank int, rawScore, combinedScore float64) {
+	if span == nil {
+		return
+	}
+	span.AddEvent("fuse.contribution", oteltrace.WithAttributes(
+		attribute.String("fuse.source", source),
+		attribute.String("fuse.item_id", itemID),
+		attribute.Int("fuse.rank", rank),
+		attribute.Float64("fuse.raw_score", rawScore),
+		attribute.Float64("fuse.combined_score", combinedScore),
+	))
+}
+
 func sortFused(items []FusedItem) {
 	if len(items) <= 1 {
 		return
@@ -149,5 +473,3 @@ func sortFused(items []FusedItem) {
 		return items[i].Score > items[j].Score
 	})
 }
-
-
@@ -0,0 +1,53 @@
+package fuse
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWeightedCombineAppliesPerSourceWeight(t *testing.T) {
+	results := []SourceResult{
+		{Source: "qdrant", Items: []Item{{ID: "a", Score: 1.0}}},
+		{Source: "bm25", Items: []Item{{ID: "b", Score: 1.0}}},
+	}
+
+	cfg := DefaultCombineConfig()
+	cfg.Weights = Weights{"bm25": 3.0}
+
+	report := WeightedCombine(context.Background(), results, cfg)
+	if len(report.Items) != 2 {
+		t.Fatalf("expected 2 fused items, got %d", len(report.Items))
+	}
+
+	var scoreA, scoreB float64
+	for _, item := range report.Items {
+		switch item.ID {
+		case "a":
+			scoreA = item.Score
+		case "b":
+			scoreB = item.Score
+		}
+	}
+
+	if scoreB <= scoreA {
+		t.Fatalf("expected bm25's 3x weight to outscore qdrant's default weight: a=%v b=%v", scoreA, scoreB)
+	}
+}
+
+func TestRRFCombineIgnoresWeights(t *testing.T) {
+	results := []SourceResult{
+		{Source: "qdrant", Items: []Item{{ID: "a", Score: 1.0}}},
+		{Source: "bm25", Items: []Item{{ID: "b", Score: 100.0}}},
+	}
+
+	cfg := DefaultCombineConfig()
+	cfg.Weights = Weights{"bm25": 3.0}
+
+	fused := RRFCombine(context.Background(), results, cfg)
+	if len(fused) != 2 {
+		t.Fatalf("expected 2 fused items, got %d", len(fused))
+	}
+	if fused[0].Score != fused[1].Score {
+		t.Fatalf("expected RRF to ignore source weights and raw score magnitude, got %v vs %v", fused[0].Score, fused[1].Score)
+	}
+}
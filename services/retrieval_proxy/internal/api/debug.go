@@ -0,0 +1,115 @@
+package api
+
+// mvp-5
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errMethodNotAllowed = errors.New("method not allowed")
+	errSearchNotFound   = errors.New("search not found")
+)
+
+// NewDebugHandler exposes the SearchIndex over HTTP:
+//
+//	GET /debug/searches?ret_code=DEGRADED&since=5m&min_ms=500&query_substr=foo
+//	GET /debug/searches/{trace_id}
+//
+// mvp-5
+func NewDebugHandler(idx *SearchIndex, buildTraceURL func(traceID string) string) http.Handler {
+	return &debugHandler{idx: idx, buildTraceURL: buildTraceURL}
+}
+
+type debugHandler struct {
+	idx           *SearchIndex
+	buildTraceURL func(traceID string) string
+}
+
+func (h *debugHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errMethodNotAllowed)
+		return
+	}
+
+	const prefix = "/debug/searches"
+	path := strings.TrimPrefix(req.URL.Path, prefix)
+	path = strings.Trim(path, "/")
+
+	if path != "" {
+		h.serveOne(w, path)
+		return
+	}
+	h.serveList(w, req)
+}
+
+func (h *debugHandler) serveOne(w http.ResponseWriter, traceID string) {
+	rec, ok := h.idx.Get(traceID)
+	if !ok {
+		writeError(w, http.StatusNotFound, errSearchNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, debugRecord(rec, h.buildTraceURL))
+}
+
+func (h *debugHandler) serveList(w http.ResponseWriter, req *http.Request) {
+	values := req.URL.Query()
+
+	filter := SearchFilter{
+		RetCode:     values.Get("ret_code"),
+		QuerySubstr: values.Get("query_substr"),
+	}
+	if since := values.Get("since"); since != "" {
+		if d, err := time.ParseDuration(since); err == nil {
+			filter.Since = d
+		}
+	}
+	if minMS := values.Get("min_ms"); minMS != "" {
+		if n, err := strconv.ParseInt(minMS, 10, 64); err == nil {
+			filter.MinMS = n
+		}
+	}
+
+	records := h.idx.Filter(filter)
+	out := make([]map[string]any, 0, len(records))
+	for _, rec := range records {
+		out = append(out, debugRecord(rec, h.buildTraceURL))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"searches": out})
+}
+
+func debugRecord(rec SearchRecord, buildTraceURL func(string) string) map[string]any {
+	out := map[string]any{
+		"trace_id":       rec.TraceID,
+		"query":          rec.Query,
+		"k":              rec.K,
+		"sources":        rec.Sources,
+		"ret_code":       rec.RetCode,
+		"degraded":       rec.Degraded,
+		"cache_hit":      rec.CacheHit,
+		"budget_hit":     rec.BudgetHit,
+		"total_ms":       rec.TotalMS,
+		"per_source_ms":  rec.PerSourceMS,
+		"policy_version": rec.PolicyVersion,
+		"timestamp":      rec.Timestamp.UTC().Format(time.RFC3339Nano),
+	}
+	if buildTraceURL != nil {
+		if url := buildTraceURL(rec.TraceID); url != "" {
+			out["trace_url"] = url
+		}
+	}
+	return out
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func sortRecordsDesc(recs []SearchRecord) {
+	sort.Slice(recs, func(i, j int) bool { return recs[i].Timestamp.After(recs[j].Timestamp) })
+}
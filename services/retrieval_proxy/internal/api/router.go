@@ -9,54 +9,135 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/searchforge/retrieval_proxy/internal/contract"
 	"github.com/searchforge/retrieval_proxy/internal/controller"
 	"github.com/searchforge/retrieval_proxy/obs"
 	"github.com/searchforge/retrieval_proxy/policy"
 )
 
+// tracerName identifies this package's spans in exported trace data.
+// mvp-5
+const tracerName = "github.com/searchforge/retrieval_proxy/internal/api"
+
+// defaultMaxBodyBytes bounds the size of a POST /v1/search JSON body.
+const defaultMaxBodyBytes = 1 << 20 // 1 MiB
+
 type handler struct {
-	ctrl          *controller.Controller
-	defaultK      int
-	defaultBudget int
-	topKMax       int
+	ctrl           *controller.Controller
+	defaultK       int
+	defaultBudget  int
+	topKMax        int
+	index          *SearchIndex
+	policyVersion  string
+	tracerProvider trace.TracerProvider
+	maxBodyBytes   int64
+}
+
+// HandlerOption configures optional handler behaviour.
+// mvp-5
+type HandlerOption func(*handler)
+
+// WithSearchIndex records every Search invocation into idx for later
+// inspection via NewDebugHandler.
+// mvp-5
+func WithSearchIndex(idx *SearchIndex) HandlerOption {
+	return func(h *handler) { h.index = idx }
+}
+
+// WithPolicyVersion tags every recorded SearchRecord with the active policy
+// version, mirroring the controller's own cache-key component.
+// mvp-5
+func WithPolicyVersion(version string) HandlerOption {
+	return func(h *handler) { h.policyVersion = version }
+}
+
+// WithTracerProvider overrides the otel TracerProvider used to start server
+// spans, e.g. to inject an in-memory exporter in tests. Defaults to the
+// global provider set by obs.InitTracer.
+// mvp-5
+func WithTracerProvider(tp trace.TracerProvider) HandlerOption {
+	return func(h *handler) { h.tracerProvider = tp }
+}
+
+// WithMaxBodyBytes bounds the size of a POST /v1/search JSON body. Defaults
+// to defaultMaxBodyBytes.
+// mvp-5
+func WithMaxBodyBytes(n int) HandlerOption {
+	return func(h *handler) { h.maxBodyBytes = int64(n) }
 }
 
 // NewHandler returns an http.Handler for /v1/search.
 // mvp-5
-func NewHandler(ctrl *controller.Controller, defaultK, defaultBudget, topKMax int) http.Handler {
-	return &handler{
-		ctrl:          ctrl,
-		defaultK:      defaultK,
-		defaultBudget: defaultBudget,
-		topKMax:       topKMax,
+func NewHandler(ctrl *controller.Controller, defaultK, defaultBudget, topKMax int, opts ...HandlerOption) http.Handler {
+	h := &handler{
+		ctrl:           ctrl,
+		defaultK:       defaultK,
+		defaultBudget:  defaultBudget,
+		topKMax:        topKMax,
+		tracerProvider: otel.GetTracerProvider(),
+		maxBodyBytes:   defaultMaxBodyBytes,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	if req.Method != http.MethodGet {
-		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
-		return
-	}
-
 	start := time.Now()
 	traceID, traceParent := readTrace(req)
 	w.Header().Set(contract.TraceIDHeader, traceID)
 
-	searchReq, err := h.buildRequest(req, traceID, traceParent)
+	var searchReq contract.Request
+	var err error
+	switch req.Method {
+	case http.MethodGet:
+		searchReq, err = h.buildRequest(req, traceID, traceParent)
+	case http.MethodPost:
+		searchReq, err = h.buildRequestFromBody(req, traceID, traceParent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed"))
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusBadRequest, err)
 		return
 	}
+	// TenantID currently comes from a plain header; a JWT claim-based
+	// extraction can populate the same field once auth middleware exists.
+	searchReq.TenantID = req.Header.Get(contract.TenantIDHeader)
 
-	ctx, cancel, budget := policy.BudgetArbiter(req.Context(), searchReq.BudgetMS)
-	defer cancel()
+	spanCtx := withIncomingTraceContext(req.Context(), traceID, traceParent, req.Header.Get("tracestate"))
+	ctx, span := h.tracerProvider.Tracer(tracerName).Start(spanCtx, "search", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+	span.SetAttributes(
+		attribute.String("http.method", req.Method),
+		attribute.String("http.target", req.URL.Path),
+		attribute.String("search.q", searchReq.Query),
+		attribute.Int("search.k", searchReq.K),
+		attribute.Int("search.budget_ms", searchReq.BudgetMS),
+		attribute.String("search.tenant_id", searchReq.TenantID),
+	)
+
+	arbiter, err := policy.NewBudgetArbiter(ctx, searchReq.BudgetMS, nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	defer arbiter.Cancel()
+	ctx = arbiter.Context()
+	budget := arbiter.Result()
 	ctx = contract.WithTraceID(ctx, traceID)
 
 	resp, retCode, callErr := h.ctrl.Search(ctx, searchReq)
@@ -89,11 +170,45 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		}
 	}
 
-	obs.ObserveProxyRequest(resp.RetCode, duration, traceID)
+	span.SetAttributes(
+		attribute.String("search.ret_code", resp.RetCode),
+		attribute.Bool("search.degraded", resp.Degraded),
+		attribute.Int("http.status_code", status),
+	)
+	if callErr != nil {
+		span.RecordError(callErr)
+	}
+
+	obs.ObserveProxyRequest(resp.RetCode, duration, traceID, searchReq.TenantID)
 	log.Printf("trace_id=%s route=proxy ret_code=%s degraded=%t duration_ms=%d status=%d", traceID, resp.RetCode, resp.Degraded, duration.Milliseconds(), status)
+	h.recordSearch(searchReq, resp, budget.Hit())
 	writeJSON(w, status, resp)
 }
 
+func (h *handler) recordSearch(req contract.Request, resp contract.Response, budgetHit bool) {
+	if h.index == nil {
+		return
+	}
+	sources := make([]string, 0, len(resp.Timings.PerSource))
+	for source := range resp.Timings.PerSource {
+		sources = append(sources, source)
+	}
+	h.index.Record(SearchRecord{
+		TraceID:       req.TraceID,
+		Query:         req.Query,
+		K:             req.K,
+		Sources:       sources,
+		RetCode:       resp.RetCode,
+		Degraded:      resp.Degraded,
+		CacheHit:      resp.Timings.CacheHit,
+		BudgetHit:     budgetHit,
+		TotalMS:       resp.Timings.TotalMS,
+		PerSourceMS:   resp.Timings.PerSource,
+		PolicyVersion: h.policyVersion,
+		Timestamp:     time.Now(),
+	})
+}
+
 func (h *handler) buildRequest(req *http.Request, traceID, traceParent string) (contract.Request, error) {
 	values := req.URL.Query()
 
@@ -119,6 +234,73 @@ func (h *handler) buildRequest(req *http.Request, traceID, traceParent string) (
 	return searchReq, nil
 }
 
+// searchRequestBody is the JSON wire format accepted by POST /v1/search,
+// mirroring contract.Request but keyed for a request body rather than
+// query params.
+// mvp-5
+type searchRequestBody struct {
+	Query         string                `json:"query"`
+	K             int                   `json:"k"`
+	BudgetMS      int                   `json:"budget_ms"`
+	Vector        []float32             `json:"vector,omitempty"`
+	VectorModel   string                `json:"vector_model,omitempty"`
+	Filters       *contract.FilterGroup `json:"filters,omitempty"`
+	SourceWeights map[string]float64    `json:"source_weights,omitempty"`
+	MinScore      float64               `json:"min_score,omitempty"`
+	Sources       []string              `json:"sources,omitempty"`
+}
+
+// buildRequestFromBody parses and validates a POST /v1/search JSON body,
+// rejecting bodies over h.maxBodyBytes.
+// mvp-5
+func (h *handler) buildRequestFromBody(req *http.Request, traceID, traceParent string) (contract.Request, error) {
+	raw, err := io.ReadAll(io.LimitReader(req.Body, h.maxBodyBytes+1))
+	if err != nil {
+		return contract.Request{}, fmt.Errorf("reading request body: %w", err)
+	}
+	if int64(len(raw)) > h.maxBodyBytes {
+		return contract.Request{}, fmt.Errorf("request body exceeds %d bytes", h.maxBodyBytes)
+	}
+
+	var body searchRequestBody
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return contract.Request{}, fmt.Errorf("invalid request body: %w", err)
+	}
+
+	query := normalizeQuery(body.Query)
+	if query == "" {
+		return contract.Request{}, fmt.Errorf("query required")
+	}
+
+	k := body.K
+	if k <= 0 {
+		k = h.defaultK
+	}
+	budget := body.BudgetMS
+	if budget <= 0 {
+		budget = h.defaultBudget
+	}
+
+	searchReq := contract.Request{
+		Query:         query,
+		K:             k,
+		BudgetMS:      budget,
+		TraceID:       traceID,
+		TraceParent:   traceParent,
+		Vector:        body.Vector,
+		VectorModel:   body.VectorModel,
+		Filters:       body.Filters,
+		SourceWeights: body.SourceWeights,
+		MinScore:      body.MinScore,
+		Sources:       body.Sources,
+	}
+
+	if err := searchReq.Validate(h.topKMax); err != nil {
+		return contract.Request{}, err
+	}
+	return searchReq, nil
+}
+
 func readTrace(req *http.Request) (string, string) {
 	traceID := req.Header.Get(contract.TraceIDHeader)
 	if traceID == "" {
@@ -131,9 +313,8 @@ func readTrace(req *http.Request) (string, string) {
 	}
 
 	if traceID == "" && traceParent != "" {
-		parts := strings.Split(traceParent, "-")
-		if len(parts) >= 2 && len(parts[1]) == 32 {
-			traceID = parts[1]
+		if sc, ok := parseTraceParent(traceParent, ""); ok {
+			traceID = sc.TraceID().String()
 		}
 	}
 
@@ -180,5 +361,9 @@ func writeJSON(w http.ResponseWriter, status int, payload any) {
 }
 
 func writeError(w http.ResponseWriter, status int, err error) {
-	writeJSON(w, status, map[string]string{"error": err.Error()})
+	retCode := "ERROR"
+	if status == http.StatusBadRequest {
+		retCode = "BAD_REQUEST"
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error(), "ret_code": retCode})
 }
@@ -0,0 +1,172 @@
+package api
+
+// mvp-5
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultIndexShards   = 16
+	defaultIndexCapacity = 10000
+)
+
+// SearchRecord captures a single Controller.Search invocation for post-hoc
+// debugging via /debug/searches.
+// mvp-5
+type SearchRecord struct {
+	TraceID       string
+	Query         string
+	K             int
+	Sources       []string
+	RetCode       string
+	Degraded      bool
+	CacheHit      bool
+	BudgetHit     bool
+	TotalMS       int64
+	PerSourceMS   map[string]int64
+	PolicyVersion string
+	Timestamp     time.Time
+}
+
+// SearchLogger receives a copy of every recorded SearchRecord, e.g. to ship
+// them to Kafka or S3, without coupling the in-memory index to disk/network
+// I/O.
+// mvp-5
+type SearchLogger interface {
+	LogSearch(rec SearchRecord)
+}
+
+// SearchIndex is a bounded, sharded ring buffer of recent SearchRecords. It
+// is safe for concurrent use and never blocks: once a shard is saturated,
+// the oldest entry in that shard is overwritten.
+// mvp-5
+type SearchIndex struct {
+	shards []*indexShard
+	sink   SearchLogger
+}
+
+type indexShard struct {
+	head  uint64
+	slots []atomic.Pointer[SearchRecord]
+}
+
+// NewSearchIndex returns a SearchIndex holding up to capacity records total,
+// spread across shards to reduce write contention. Each shard gets
+// capacity/16 slots, rounded down but never fewer than one, so a capacity
+// below 16 still holds 16 records. A nil sink disables
+// forwarding. capacity <= 0 falls back to a sane default.
+// mvp-5
+func NewSearchIndex(capacity int, sink SearchLogger) *SearchIndex {
+	if capacity <= 0 {
+		capacity = defaultIndexCapacity
+	}
+	perShard := capacity / defaultIndexShards
+	if perShard < 1 {
+		perShard = 1
+	}
+	shards := make([]*indexShard, defaultIndexShards)
+	for i := range shards {
+		shards[i] = &indexShard{slots: make([]atomic.Pointer[SearchRecord], perShard)}
+	}
+	return &SearchIndex{shards: shards, sink: sink}
+}
+
+// Record stores rec, overwriting the oldest entry in its shard if full, and
+// forwards it to the configured sink if any.
+// mvp-5
+func (idx *SearchIndex) Record(rec SearchRecord) {
+	if idx == nil {
+		return
+	}
+	shard := idx.shards[shardFor(rec.TraceID, len(idx.shards))]
+	slot := atomic.AddUint64(&shard.head, 1) - 1
+	copied := rec
+	shard.slots[slot%uint64(len(shard.slots))].Store(&copied)
+
+	if idx.sink != nil {
+		idx.sink.LogSearch(rec)
+	}
+}
+
+// Get returns the most recent record matching traceID, if still present.
+// mvp-5
+func (idx *SearchIndex) Get(traceID string) (SearchRecord, bool) {
+	if idx == nil {
+		return SearchRecord{}, false
+	}
+	var best SearchRecord
+	found := false
+	for _, rec := range idx.snapshot() {
+		if rec.TraceID == traceID && (!found || rec.Timestamp.After(best.Timestamp)) {
+			best = rec
+			found = true
+		}
+	}
+	return best, found
+}
+
+// SearchFilter narrows a SearchIndex.Filter query.
+// mvp-5
+type SearchFilter struct {
+	RetCode     string
+	Since       time.Duration
+	MinMS       int64
+	QuerySubstr string
+}
+
+// Filter returns records matching f, newest first.
+// mvp-5
+func (idx *SearchIndex) Filter(f SearchFilter) []SearchRecord {
+	if idx == nil {
+		return nil
+	}
+	cutoff := time.Time{}
+	if f.Since > 0 {
+		cutoff = time.Now().Add(-f.Since)
+	}
+
+	var out []SearchRecord
+	for _, rec := range idx.snapshot() {
+		if f.RetCode != "" && rec.RetCode != f.RetCode {
+			continue
+		}
+		if f.MinMS > 0 && rec.TotalMS < f.MinMS {
+			continue
+		}
+		if !cutoff.IsZero() && rec.Timestamp.Before(cutoff) {
+			continue
+		}
+		if f.QuerySubstr != "" && !containsFold(rec.Query, f.QuerySubstr) {
+			continue
+		}
+		out = append(out, rec)
+	}
+	sortRecordsDesc(out)
+	return out
+}
+
+func (idx *SearchIndex) snapshot() []SearchRecord {
+	var out []SearchRecord
+	for _, shard := range idx.shards {
+		for i := range shard.slots {
+			if rec := shard.slots[i].Load(); rec != nil {
+				out = append(out, *rec)
+			}
+		}
+	}
+	return out
+}
+
+func shardFor(traceID string, n int) int {
+	if n <= 1 {
+		return 0
+	}
+	var h uint32 = 2166136261
+	for i := 0; i < len(traceID); i++ {
+		h ^= uint32(traceID[i])
+		h *= 16777619
+	}
+	return int(h % uint32(n))
+}
@@ -0,0 +1,85 @@
+package api
+
+// mvp-5
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// parseTraceParent parses a W3C traceparent header (version-trace_id-parent_id-flags)
+// together with an optional tracestate header into a remote otel SpanContext.
+// It reports false if traceparent is absent or malformed, per the W3C spec's
+// guidance to treat an invalid header as if none were sent.
+// mvp-5
+func parseTraceParent(traceparent, tracestate string) (trace.SpanContext, bool) {
+	if traceparent == "" {
+		return trace.SpanContext{}, false
+	}
+
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 {
+		return trace.SpanContext{}, false
+	}
+	version, traceIDHex, parentIDHex, flagsHex := parts[0], parts[1], parts[2], parts[3]
+	if len(version) != 2 || len(traceIDHex) != 32 || len(parentIDHex) != 16 || len(flagsHex) != 2 {
+		return trace.SpanContext{}, false
+	}
+	if version == "ff" {
+		return trace.SpanContext{}, false
+	}
+
+	traceIDBytes, err := hex.DecodeString(traceIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	parentIDBytes, err := hex.DecodeString(parentIDHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+	flagsByte, err := hex.DecodeString(flagsHex)
+	if err != nil {
+		return trace.SpanContext{}, false
+	}
+
+	var traceID trace.TraceID
+	copy(traceID[:], traceIDBytes)
+	var parentID trace.SpanID
+	copy(parentID[:], parentIDBytes)
+
+	if !traceID.IsValid() || !parentID.IsValid() {
+		return trace.SpanContext{}, false
+	}
+
+	state, err := trace.ParseTraceState(tracestate)
+	if err != nil {
+		state = trace.TraceState{}
+	}
+
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     parentID,
+		TraceFlags: trace.TraceFlags(flagsByte[0]),
+		TraceState: state,
+		Remote:     true,
+	}), true
+}
+
+// withIncomingTraceContext attaches the remote trace context carried by an
+// inbound request to ctx: the full W3C traceparent/tracestate when present
+// and well-formed, or else just the resolved trace ID (e.g. one freshly
+// generated by readTrace) so the server span's own trace ID agrees with
+// what callers see echoed back in the X-Trace-Id response header.
+// mvp-5
+func withIncomingTraceContext(ctx context.Context, traceID, traceParent, traceState string) context.Context {
+	if sc, ok := parseTraceParent(traceParent, traceState); ok {
+		return trace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+	if tid, err := trace.TraceIDFromHex(traceID); err == nil {
+		return trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: tid}))
+	}
+	return ctx
+}
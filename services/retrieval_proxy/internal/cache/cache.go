@@ -0,0 +1,94 @@
+// Package cache provides response-level caching for the controller's
+// retrieval pipeline, with a pluggable storage backend and short-TTL
+// negative caching for upstream 4xx errors.
+package cache
+
+// mvp-5
+
+import (
+	"context"
+	"time"
+
+	"github.com/searchforge/retrieval_proxy/internal/contract"
+	"github.com/searchforge/retrieval_proxy/obs"
+)
+
+// Entry captures a cached controller response. Negative entries record an
+// upstream failure (e.g. a Qdrant 4xx) so the controller can short-circuit
+// a repeat of the same bad request without re-dispatching it, typically
+// under a shorter TTL than a successful result.
+type Entry struct {
+	Items            []contract.Item
+	PerSource        map[string]int64
+	TotalMS          int64
+	Degraded         bool
+	RetCode          string
+	Negative         bool
+	RewrittenQueries []string
+}
+
+// Backend is a pluggable cache store. Tier identifies the backend for
+// metrics purposes (e.g. "memory", "redis").
+type Backend interface {
+	Tier() string
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error
+	Close() error
+}
+
+// Cache wraps a Backend with the TTL policy shared by every tier: a normal
+// TTL for successful responses and a shorter NegativeTTL for cached
+// upstream failures, so a misbehaving client isn't re-hammered but a
+// transient Qdrant error doesn't stick around as long as a real result.
+type Cache struct {
+	backend     Backend
+	ttl         time.Duration
+	negativeTTL time.Duration
+}
+
+// NewCache wraps backend with the given TTLs. A zero ttl disables caching of
+// successful results; a zero negativeTTL independently disables negative
+// caching. Either, both, or neither may be active at once.
+func NewCache(backend Backend, ttl, negativeTTL time.Duration) *Cache {
+	return &Cache{backend: backend, ttl: ttl, negativeTTL: negativeTTL}
+}
+
+// Get retrieves a fresh entry for key, if any, positive or negative. The
+// bool return is false on a miss, on an expired entry, or when there is no
+// backend at all; it does not depend on c.ttl, since Set already refuses to
+// write an entry whose governing TTL (ttl or negativeTTL) is non-positive.
+func (c *Cache) Get(ctx context.Context, key string) (Entry, bool) {
+	if c == nil || c.backend == nil {
+		return Entry{}, false
+	}
+	entry, ok, err := c.backend.Get(ctx, key)
+	if err != nil || !ok {
+		return Entry{}, false
+	}
+	obs.IncCacheHit(c.backend.Tier())
+	return entry, true
+}
+
+// Set stores entry under key, using NegativeTTL when entry.Negative is set
+// and the configured Cache TTL otherwise.
+func (c *Cache) Set(ctx context.Context, key string, entry Entry) {
+	if c == nil || c.backend == nil {
+		return
+	}
+	ttl := c.ttl
+	if entry.Negative {
+		ttl = c.negativeTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	_ = c.backend.Set(ctx, key, entry, ttl)
+}
+
+// Close releases the underlying backend's resources.
+func (c *Cache) Close() error {
+	if c == nil || c.backend == nil {
+		return nil
+	}
+	return c.backend.Close()
+}
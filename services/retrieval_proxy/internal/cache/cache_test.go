@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCacheNegativeCachingIndependentOfPositiveTTL(t *testing.T) {
+	c := NewCache(NewMemoryBackend(0), 0, 2*time.Second)
+
+	c.Set(context.Background(), "k", Entry{RetCode: "DEGRADED", Negative: true})
+	entry, ok := c.Get(context.Background(), "k")
+	if !ok {
+		t.Fatal("expected negative entry to be readable even though positive TTL is disabled")
+	}
+	if !entry.Negative || entry.RetCode != "DEGRADED" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestCachePositiveCachingDisabledByZeroTTL(t *testing.T) {
+	c := NewCache(NewMemoryBackend(0), 0, 2*time.Second)
+
+	c.Set(context.Background(), "k", Entry{RetCode: "OK"})
+	if _, ok := c.Get(context.Background(), "k"); ok {
+		t.Fatal("expected a positive entry never to be written when TTL <= 0")
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	c := NewCache(NewMemoryBackend(0), time.Minute, time.Second)
+
+	c.Set(context.Background(), "k", Entry{RetCode: "OK", TotalMS: 42})
+	entry, ok := c.Get(context.Background(), "k")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if entry.TotalMS != 42 {
+		t.Fatalf("expected TotalMS 42, got %d", entry.TotalMS)
+	}
+}
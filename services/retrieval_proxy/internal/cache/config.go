@@ -0,0 +1,42 @@
+package cache
+
+// mvp-5
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config selects and sizes the cache backend.
+type Config struct {
+	// TTL is how long a successful response stays cached. TTL <= 0
+	// disables caching of successful responses.
+	TTL time.Duration
+	// NegativeTTL is how long a cached upstream 4xx failure stays cached,
+	// typically much shorter than TTL. It is independent of TTL: either can
+	// be active while the other is <= 0.
+	NegativeTTL time.Duration
+	// Capacity bounds the in-memory backend; ignored when RedisAddr is set.
+	Capacity int
+	// RedisAddr, when set, selects the Redis backend over the in-memory
+	// default.
+	RedisAddr string
+	// RedisPrefix namespaces keys within a shared Redis instance.
+	RedisPrefix string
+}
+
+// New builds a Cache using the Redis backend when cfg.RedisAddr is set, or
+// the in-memory LRU backend otherwise.
+func New(cfg Config) (*Cache, error) {
+	var backend Backend
+	if cfg.RedisAddr != "" {
+		redisBackend, err := NewRedisBackend(cfg.RedisAddr, cfg.RedisPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("cache: %w", err)
+		}
+		backend = redisBackend
+	} else {
+		backend = NewMemoryBackend(cfg.Capacity)
+	}
+	return NewCache(backend, cfg.TTL, cfg.NegativeTTL), nil
+}
@@ -0,0 +1,60 @@
+package cache
+
+// mvp-5
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	"github.com/searchforge/retrieval_proxy/internal/contract"
+)
+
+// BuildKey hashes the parameters that influence retrieval output:
+// policyVersion, the active source set, the normalized query, k, a hash of
+// the request's filters, the dense vector and its model (when hybrid
+// retrieval is in play), any per-source fusion weight overrides, and the
+// minimum score threshold. sources need not be pre-sorted; BuildKey sorts a
+// copy so caller-order never affects the key.
+func BuildKey(policyVersion string, sources []string, query string, k int, filterHash string, vector []float32, vectorModel string, sourceWeights map[string]float64, minScore float64) string {
+	sorted := append([]string(nil), sources...)
+	for i := 1; i < len(sorted); i++ {
+		for j := i; j > 0 && sorted[j-1] > sorted[j]; j-- {
+			sorted[j-1], sorted[j] = sorted[j], sorted[j-1]
+		}
+	}
+
+	payload := map[string]any{
+		"policy_version": policyVersion,
+		"sources":        sorted,
+		"query":          normalizeQuery(query),
+		"k":              k,
+		"filter_hash":    filterHash,
+		"vector":         vector,
+		"vector_model":   vectorModel,
+		"source_weights": sourceWeights,
+		"min_score":      minScore,
+	}
+	raw, _ := json.Marshal(payload)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeQuery canonicalizes a query string so equivalent queries that
+// differ only in case or surrounding whitespace share a cache entry.
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
+
+// HashFilters returns a stable hash of a request's filter tree, or the
+// empty string for a nil FilterGroup, so unfiltered requests share a
+// cache key regardless of hashing details.
+func HashFilters(filters *contract.FilterGroup) string {
+	if filters == nil {
+		return ""
+	}
+	raw, _ := json.Marshal(filters)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
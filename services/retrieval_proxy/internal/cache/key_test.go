@@ -0,0 +1,24 @@
+package cache
+
+import "testing"
+
+func TestBuildKeyDiffersByVector(t *testing.T) {
+	base := BuildKey("v1", []string{"qdrant"}, "q", 10, "", []float32{1, 2}, "model-a", nil, 0)
+	other := BuildKey("v1", []string{"qdrant"}, "q", 10, "", []float32{3, 4}, "model-a", nil, 0)
+	if base == other {
+		t.Fatal("expected different vectors to produce different cache keys")
+	}
+}
+
+func TestBuildKeyDiffersBySourceWeightsAndMinScore(t *testing.T) {
+	base := BuildKey("v1", []string{"qdrant"}, "q", 10, "", nil, "", nil, 0)
+	weighted := BuildKey("v1", []string{"qdrant"}, "q", 10, "", nil, "", map[string]float64{"qdrant": 2}, 0)
+	scored := BuildKey("v1", []string{"qdrant"}, "q", 10, "", nil, "", nil, 0.5)
+
+	if base == weighted {
+		t.Fatal("expected source weight overrides to produce a different cache key")
+	}
+	if base == scored {
+		t.Fatal("expected a different min score to produce a different cache key")
+	}
+}
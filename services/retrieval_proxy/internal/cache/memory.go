@@ -0,0 +1,94 @@
+package cache
+
+// mvp-5
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// defaultMemoryCapacity bounds MemoryBackend when Config.Capacity is unset.
+const defaultMemoryCapacity = 10000
+
+type memoryItem struct {
+	key       string
+	entry     Entry
+	expiresAt time.Time
+}
+
+// MemoryBackend is an in-process, LRU-evicting cache. It is the default
+// backend used when no external cache is configured.
+type MemoryBackend struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+// NewMemoryBackend returns a backend holding at most capacity entries,
+// evicting the least recently used once full. capacity <= 0 falls back to
+// defaultMemoryCapacity.
+func NewMemoryBackend(capacity int) *MemoryBackend {
+	if capacity <= 0 {
+		capacity = defaultMemoryCapacity
+	}
+	return &MemoryBackend{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Tier identifies this backend for cache-hit metrics.
+func (m *MemoryBackend) Tier() string { return "memory" }
+
+// Get implements Backend.
+func (m *MemoryBackend) Get(_ context.Context, key string) (Entry, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if !ok {
+		return Entry{}, false, nil
+	}
+	item := el.Value.(*memoryItem)
+	if time.Now().After(item.expiresAt) {
+		m.ll.Remove(el)
+		delete(m.items, key)
+		return Entry{}, false, nil
+	}
+	m.ll.MoveToFront(el)
+	return item.entry, true, nil
+}
+
+// Set implements Backend.
+func (m *MemoryBackend) Set(_ context.Context, key string, entry Entry, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryItem).entry = entry
+		el.Value.(*memoryItem).expiresAt = expiresAt
+		m.ll.MoveToFront(el)
+		return nil
+	}
+
+	el := m.ll.PushFront(&memoryItem{key: key, entry: entry, expiresAt: expiresAt})
+	m.items[key] = el
+
+	for m.ll.Len() > m.capacity {
+		oldest := m.ll.Back()
+		if oldest == nil {
+			break
+		}
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryItem).key)
+	}
+	return nil
+}
+
+// Close implements Backend; MemoryBackend holds no closable resources.
+func (m *MemoryBackend) Close() error { return nil }
@@ -0,0 +1,66 @@
+package cache
+
+// mvp-5
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisBackend stores entries in Redis, letting multiple controller
+// instances share a response cache. Selected via REDIS_ADDR; the
+// in-memory backend remains the default.
+type RedisBackend struct {
+	client *redis.Client
+	prefix string
+}
+
+// NewRedisBackend dials addr and returns a backend namespacing keys under
+// prefix, so one Redis instance can host more than one cache.
+func NewRedisBackend(addr, prefix string) (*RedisBackend, error) {
+	if addr == "" {
+		return nil, fmt.Errorf("redis addr required")
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+	if err := client.Ping(context.Background()).Err(); err != nil {
+		return nil, fmt.Errorf("redis ping: %w", err)
+	}
+	return &RedisBackend{client: client, prefix: prefix}, nil
+}
+
+// Tier identifies this backend for cache-hit metrics.
+func (r *RedisBackend) Tier() string { return "redis" }
+
+// Get implements Backend.
+func (r *RedisBackend) Get(ctx context.Context, key string) (Entry, bool, error) {
+	raw, err := r.client.Get(ctx, r.prefix+key).Bytes()
+	if err == redis.Nil {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return Entry{}, false, err
+	}
+	return entry, true, nil
+}
+
+// Set implements Backend.
+func (r *RedisBackend) Set(ctx context.Context, key string, entry Entry, ttl time.Duration) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return r.client.Set(ctx, r.prefix+key, raw, ttl).Err()
+}
+
+// Close implements Backend.
+func (r *RedisBackend) Close() error {
+	return r.client.Close()
+}
@@ -9,7 +9,12 @@ import (
 
 const TraceIDHeader = "X-Trace-Id"
 
-// Request captures inbound search parameters.
+// TenantIDHeader names the header tenants are identified by in the absence
+// of a JWT claim; see Request.TenantID.
+const TenantIDHeader = "X-Tenant-Id"
+
+// Request captures inbound search parameters, for both the GET /v1/search
+// query-param path and the richer POST /v1/search JSON body path.
 // mvp-5
 type Request struct {
 	Query       string
@@ -17,6 +22,104 @@ type Request struct {
 	BudgetMS    int
 	TraceID     string
 	TraceParent string
+
+	// TenantID identifies the caller for tenant-scoped rate limiting and
+	// budget overrides. It is populated from TenantIDHeader; a future JWT
+	// claim-based extraction can populate the same field without touching
+	// downstream policy code.
+	TenantID string
+
+	// Vector, when non-empty, supplies a dense embedding for hybrid
+	// dense+sparse retrieval. VectorModel identifies which registered
+	// model produced it, so its dimension can be validated up front.
+	Vector      []float32
+	VectorModel string
+
+	// Filters restricts results to items matching the given predicates.
+	// A nil Filters matches everything.
+	Filters *FilterGroup
+
+	// SourceWeights overrides the fusion weight applied to each named
+	// upstream source; a source absent from the map uses fuse's default.
+	SourceWeights map[string]float64
+
+	// MinScore drops fused results scoring below this threshold.
+	MinScore float64
+
+	// Sources, when non-empty, restricts the controller to fanning out to
+	// only the named upstream sources.
+	Sources []string
+}
+
+// FilterOp is a comparison operator usable in a Filter predicate.
+type FilterOp string
+
+const (
+	FilterEq  FilterOp = "eq"
+	FilterNeq FilterOp = "neq"
+	FilterGt  FilterOp = "gt"
+	FilterGte FilterOp = "gte"
+	FilterLt  FilterOp = "lt"
+	FilterLte FilterOp = "lte"
+	FilterIn  FilterOp = "in"
+)
+
+// Filter is a single field/op/value predicate used by FilterGroup.
+type Filter struct {
+	Field string      `json:"field"`
+	Op    FilterOp    `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+// FilterGroup groups Filters (and nested FilterGroups) under an AND or OR
+// combinator. The zero value matches everything.
+type FilterGroup struct {
+	Combinator string        `json:"combinator,omitempty"` // "and" (default) or "or"
+	Filters    []Filter      `json:"filters,omitempty"`
+	Groups     []FilterGroup `json:"groups,omitempty"`
+}
+
+func (g *FilterGroup) validate() error {
+	if g == nil {
+		return nil
+	}
+	switch g.Combinator {
+	case "", "and", "or":
+	default:
+		return fmt.Errorf("unknown filter combinator %q", g.Combinator)
+	}
+	for _, f := range g.Filters {
+		if f.Field == "" {
+			return fmt.Errorf("filter field required")
+		}
+		switch f.Op {
+		case FilterEq, FilterNeq, FilterGt, FilterGte, FilterLt, FilterLte, FilterIn:
+		default:
+			return fmt.Errorf("unknown filter op %q", f.Op)
+		}
+	}
+	for i := range g.Groups {
+		if err := g.Groups[i].validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// vectorModelDims registers the known embedding models and their
+// dimensionality, used to validate Request.Vector against
+// Request.VectorModel.
+var vectorModelDims = map[string]int{
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
+	"minilm-l6-v2":           384,
+}
+
+// VectorModelDimension returns the expected embedding dimension for a
+// registered model name.
+func VectorModelDimension(model string) (int, bool) {
+	dim, ok := vectorModelDims[model]
+	return dim, ok
 }
 
 // Validate ensures the inbound request parameters are consistent.
@@ -34,6 +137,26 @@ func (r Request) Validate(maxK int) error {
 	if r.BudgetMS <= 0 {
 		return fmt.Errorf("budget_ms must be positive")
 	}
+	if len(r.Vector) > 0 {
+		dim, ok := VectorModelDimension(r.VectorModel)
+		if !ok {
+			return fmt.Errorf("unknown vector model %q", r.VectorModel)
+		}
+		if len(r.Vector) != dim {
+			return fmt.Errorf("vector dimension %d does not match model %q (want %d)", len(r.Vector), r.VectorModel, dim)
+		}
+	}
+	if r.MinScore < 0 {
+		return fmt.Errorf("min_score must be non-negative")
+	}
+	for name, weight := range r.SourceWeights {
+		if weight < 0 {
+			return fmt.Errorf("source weight for %q must be non-negative", name)
+		}
+	}
+	if err := r.Filters.validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -57,6 +180,12 @@ type Response struct {
 	RetCode  string `json:"ret_code"`
 	Degraded bool   `json:"degraded"`
 	TraceURL string `json:"trace_url,omitempty"`
+
+	// RewrittenQueries lists every sub-query actually dispatched to sources,
+	// in dispatch order, for observability into the rewriter pipeline. It is
+	// omitted when only one query was dispatched: the pipeline is disabled,
+	// or it produced no sub-query beyond the (possibly normalized) original.
+	RewrittenQueries []string `json:"rewritten_queries,omitempty"`
 }
 
 type contextKey string
@@ -82,4 +211,3 @@ func TraceIDFromContext(ctx context.Context) (string, bool) {
 	traceID, ok := value.(string)
 	return traceID, ok
 }
-
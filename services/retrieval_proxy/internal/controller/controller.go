@@ -9,12 +9,17 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
-	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"golang.org/x/sync/singleflight"
+
 	"github.com/searchforge/retrieval_proxy/fuse"
+	"github.com/searchforge/retrieval_proxy/internal/cache"
 	"github.com/searchforge/retrieval_proxy/internal/contract"
+	"github.com/searchforge/retrieval_proxy/internal/rewriter"
+	"github.com/searchforge/retrieval_proxy/obs"
 	"github.com/searchforge/retrieval_proxy/policy"
 	"github.com/searchforge/retrieval_proxy/sources"
 )
@@ -28,63 +33,67 @@ var (
 	ErrBadRequest = errors.New("bad request")
 )
 
-// Source defines the behaviour required by upstream retrieval sources.
-// mvp-5
-type Source interface {
-	Search(ctx context.Context, queries []sources.Query) sources.Result
-	Ping(ctx context.Context) error
+// SourceBinding wires one concrete sources.Plugin into the controller,
+// together with its name, target collection, and the policy controls that
+// gate calls to it.
+type SourceBinding struct {
+	Name       string
+	Collection string
+	Plugin     sources.Plugin
+	Policy     policy.SourceConfig
 }
 
 // Config groups controller dependencies.
 // mvp-5
 type Config struct {
-	SourceName      string
-	Collection      string
-	Policy          policy.SourceConfig
+	Sources         []SourceBinding
 	Fuse            fuse.CombineConfig
-	CacheTTL        time.Duration
+	Cache           cache.Config
 	PolicyVersion   string
 	LangfuseHost    string
 	LangfuseProject string
+
+	// Rewriter runs the query rewriting pipeline before fan-out. Nil
+	// disables rewriting, so every query is dispatched unchanged.
+	Rewriter *rewriter.Pipeline
+}
+
+// sourceState holds the per-source runtime state the controller fans
+// queries out to: the plugin itself plus the policy machinery (circuit
+// breaker, rate limiter, hedging) that gates calls to it.
+type sourceState struct {
+	name       string
+	collection string
+	plugin     sources.Plugin
+	policy     *policy.SourcePolicy
+	distRate   *policy.DistributedRateLimiter
+	hedge      policy.HedgeConfig
+	fuseConfig fuse.CombineConfig
 }
 
-// Controller coordinates policy, caching, and fusion.
+// Controller coordinates policy, caching, and fusion across one or more
+// upstream sources.
 // mvp-5
 type Controller struct {
-	source      Source
-	sourceName  string
-	collection  string
-	policy      *policy.SourcePolicy
-	fuseConfig  fuse.CombineConfig
-	cache       *Cache
-	policyHash  string
-	host        string
-	project     string
+	sources    []*sourceState
+	byName     map[string]*sourceState
+	fuseConfig fuse.CombineConfig
+	cache      *cache.Cache
+	sf         singleflight.Group
+	policyHash string
+	host       string
+	project    string
+	rewriter   *rewriter.Pipeline
+	policyCtrl *policy.Controller
 }
 
-// New constructs a controller.
+// New constructs a controller that fans queries out to every source in
+// cfg.Sources and merges their results with fuse.RRFCombine, or with
+// fuse.WeightedCombine when a request carries per-source weight overrides.
 // mvp-5
-func New(src Source, cfg Config) (*Controller, error) {
-	if src == nil {
-		return nil, fmt.Errorf("source required")
-	}
-
-	if cfg.SourceName == "" {
-		cfg.SourceName = "qdrant"
-	}
-	if cfg.Collection == "" {
-		cfg.Collection = os.Getenv("QDRANT_COLLECTION")
-	}
-
-	policyConfig := cfg.Policy
-	policyConfig.Name = cfg.SourceName
-	if policyConfig.Timeout <= 0 {
-		policyConfig.Timeout = 300 * time.Millisecond
-	}
-
-	sourcePolicy, err := policy.NewSourcePolicy(policyConfig)
-	if err != nil {
-		return nil, err
+func New(cfg Config) (*Controller, error) {
+	if len(cfg.Sources) == 0 {
+		return nil, fmt.Errorf("at least one source required")
 	}
 
 	fuseCfg := cfg.Fuse
@@ -98,22 +107,112 @@ func New(src Source, cfg Config) (*Controller, error) {
 		fuseCfg.TopKInit = fuse.DefaultCombineConfig().TopKInit
 	}
 
-	cache := NewCache(cfg.CacheTTL)
+	bindings := make([]SourceBinding, 0, len(cfg.Sources))
+	policyConfigs := make([]policy.SourceConfig, 0, len(cfg.Sources))
+	seen := make(map[string]bool, len(cfg.Sources))
+	for _, binding := range cfg.Sources {
+		if binding.Name == "" {
+			return nil, fmt.Errorf("source name required")
+		}
+		if binding.Plugin == nil {
+			return nil, fmt.Errorf("source %q: plugin required", binding.Name)
+		}
+		if seen[binding.Name] {
+			return nil, fmt.Errorf("source %q registered more than once", binding.Name)
+		}
+		seen[binding.Name] = true
+
+		policyConfig := binding.Policy
+		policyConfig.Name = binding.Name
+		if policyConfig.Timeout <= 0 {
+			policyConfig.Timeout = 300 * time.Millisecond
+		}
+
+		bindings = append(bindings, binding)
+		policyConfigs = append(policyConfigs, policyConfig)
+	}
+
+	// Build the policy controller first so every source's shared policy is
+	// constructed exactly once; sourceState.policy below is taken from it
+	// rather than built a second time, so the no-tenant and unconfigured-
+	// tenant paths resolve to the same *policy.SourcePolicy instance.
+	policyCtrl, err := policy.NewController(context.Background(), policy.ControllerConfig{Sources: policyConfigs}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("policy controller: %w", err)
+	}
+
+	states := make([]*sourceState, 0, len(bindings))
+	byName := make(map[string]*sourceState, len(bindings))
+	for i, binding := range bindings {
+		policyConfig := policyConfigs[i]
+
+		sourcePolicy, ok := policyCtrl.Source(binding.Name, "")
+		if !ok {
+			return nil, fmt.Errorf("source %q: policy not found", binding.Name)
+		}
+
+		var distRate *policy.DistributedRateLimiter
+		if policyConfig.Distributed != nil {
+			distRate, err = policy.NewDistributedRateLimiter(binding.Name, *policyConfig.Distributed, nil)
+			if err != nil {
+				return nil, fmt.Errorf("source %q: distributed rate limiter: %w", binding.Name, err)
+			}
+		}
+
+		state := &sourceState{
+			name:       binding.Name,
+			collection: binding.Collection,
+			plugin:     binding.Plugin,
+			policy:     sourcePolicy,
+			distRate:   distRate,
+			hedge:      policyConfig.Hedge,
+			fuseConfig: fuseCfg,
+		}
+		states = append(states, state)
+		byName[binding.Name] = state
+	}
+
+	respCache, err := cache.New(cfg.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("cache: %w", err)
+	}
 
 	return &Controller{
-		source:     src,
-		sourceName: cfg.SourceName,
-		collection: cfg.Collection,
-		policy:     sourcePolicy,
+		sources:    states,
+		byName:     byName,
 		fuseConfig: fuseCfg,
-		cache:      cache,
+		cache:      respCache,
 		policyHash: cfg.PolicyVersion,
 		host:       cfg.LangfuseHost,
 		project:    cfg.LangfuseProject,
+		rewriter:   cfg.Rewriter,
+		policyCtrl: policyCtrl,
 	}, nil
 }
 
-// Search executes the retrieval pipeline.
+// PolicyController returns the tenant-scoped policy controller backing
+// per-tenant rate limit and timeout overrides (policy.Controller.Source),
+// and the reload machinery (ReloadHandler, WatchReloadSignal) that lets
+// those overrides change without a process restart.
+func (c *Controller) PolicyController() *policy.Controller {
+	return c.policyCtrl
+}
+
+// resolvePolicy returns the policy that should gate a call to state for the
+// given tenant: the tenant-scoped override from c.policyCtrl if one exists,
+// falling back to state's own shared policy otherwise (including when
+// tenantID is empty, or no policy controller is configured).
+func (c *Controller) resolvePolicy(state *sourceState, tenantID string) *policy.SourcePolicy {
+	if c.policyCtrl != nil && tenantID != "" {
+		if p, ok := c.policyCtrl.Source(state.name, tenantID); ok && p != nil {
+			return p
+		}
+	}
+	return state.policy
+}
+
+// Search executes the retrieval pipeline: fan out to every active source
+// concurrently, then fuse their results.
 // mvp-5
 func (c *Controller) Search(ctx context.Context, req contract.Request) (contract.Response, string, error) {
 	var resp contract.Response
@@ -126,68 +225,284 @@ func (c *Controller) Search(ctx context.Context, req contract.Request) (contract
 		return resp, "BAD_REQUEST", ErrBadRequest
 	}
 
-	cacheKey := BuildCacheKey(req.Query, req.K, c.sourceName, c.fuseConfig, c.policyHash)
-	if entry, ok := c.cache.Get(cacheKey); ok {
+	active := c.activeSources(req.Sources)
+	if len(active) == 0 {
+		return resp, resp.RetCode, nil
+	}
+
+	cacheKey := cache.BuildKey(c.policyHash, c.cacheSourceNames(active), req.Query, req.K, cache.HashFilters(req.Filters), req.Vector, req.VectorModel, req.SourceWeights, req.MinScore)
+	if entry, ok := c.cache.Get(ctx, cacheKey); ok {
 		resp.Items = cloneItems(entry.Items)
 		resp.Timings.TotalMS = entry.TotalMS
 		resp.Timings.PerSource = cloneTiming(entry.PerSource)
 		resp.Timings.CacheHit = true
 		resp.Degraded = entry.Degraded
 		resp.RetCode = entry.RetCode
+		resp.RewrittenQueries = entry.RewrittenQueries
 		return resp, resp.RetCode, nil
 	}
 
+	// sf coalesces concurrent identical requests onto a single upstream
+	// fan-out; every coalesced caller still builds its own resp (notably
+	// TraceURL) from the shared outcome below.
+	raw, _, shared := c.sf.Do(cacheKey, func() (any, error) {
+		return c.fanOutAndFuse(ctx, req, active, cacheKey), nil
+	})
+	if shared {
+		obs.IncCacheSingleflightCoalesced()
+	}
+	outcome := raw.(searchOutcome)
+
+	resp.Items = cloneItems(outcome.items)
+	resp.Timings.TotalMS = outcome.totalMS
+	resp.Timings.PerSource = cloneTiming(outcome.perSource)
+	resp.Timings.CacheHit = false
+	resp.Degraded = outcome.degraded
+	resp.RetCode = outcome.retCode
+	resp.RewrittenQueries = outcome.rewrittenQueries
+
+	return resp, resp.RetCode, outcome.err
+}
+
+// searchOutcome is the upstream-fan-out result shared by every caller
+// coalesced onto the same cache key via Controller.sf.
+type searchOutcome struct {
+	items            []contract.Item
+	perSource        map[string]int64
+	totalMS          int64
+	degraded         bool
+	retCode          string
+	err              error
+	rewrittenQueries []string
+}
+
+// fanOutAndFuse rewrites req.Query into one or more sub-queries, dispatches
+// each to every source in active concurrently, fuses whatever succeeds
+// across every (sub-query, source) pair, and populates the response cache
+// (including a short-TTL negative entry when every source failed on every
+// sub-query and at least one failure was an upstream 4xx). It runs at most
+// once per coalesced batch of identical concurrent Search calls.
+func (c *Controller) fanOutAndFuse(ctx context.Context, req contract.Request, active []*sourceState, cacheKey string) searchOutcome {
 	start := time.Now()
-	result, timedOut, err := c.callSource(ctx, req)
-	totalMs := time.Since(start).Milliseconds()
-	resp.Timings.TotalMS = totalMs
-	resp.Timings.PerSource[c.sourceName] = result.TookMs
 
-	if err != nil {
-		resp.RetCode = "DEGRADED"
-		resp.Degraded = true
-		return resp, resp.RetCode, err
+	subQueries := c.rewriter.Rewrite(ctx, req.Query)
+
+	batches := make([][]sourceOutcome, len(subQueries))
+	var wg sync.WaitGroup
+	wg.Add(len(subQueries))
+	for i, q := range subQueries {
+		subReq := req
+		subReq.Query = q
+		go func(i int, subReq contract.Request) {
+			defer wg.Done()
+			batches[i] = c.callSources(ctx, subReq, active)
+		}(i, subReq)
+	}
+	wg.Wait()
+
+	totalMS := time.Since(start).Milliseconds()
+
+	var sourceResults []fuse.SourceResult
+	perSource := make(map[string]int64, len(active))
+	degraded := false
+	timeoutOnly := true
+	negativeCode := 0
+	var lastErr error
+	for _, outcomes := range batches {
+		for _, outcome := range outcomes {
+			if outcome.result.TookMs > perSource[outcome.name] {
+				perSource[outcome.name] = outcome.result.TookMs
+			}
+			if outcome.err != nil || outcome.timedOut {
+				degraded = true
+				if outcome.timedOut {
+					obs.IncBudgetHitSource(outcome.name)
+				} else {
+					timeoutOnly = false
+					if outcome.result.Code >= 400 && outcome.result.Code < 500 {
+						negativeCode = outcome.result.Code
+					}
+				}
+				lastErr = outcome.err
+				continue
+			}
+			sourceResults = append(sourceResults, fuse.SourceResult{
+				Source: outcome.name,
+				Items:  decodeSourceItems(outcome.result.Items),
+			})
+		}
 	}
 
-	if timedOut {
-		resp.RetCode = "UPSTREAM_TIMEOUT"
-		resp.Degraded = true
-		return resp, resp.RetCode, ErrUpstreamTimeout
+	if len(sourceResults) == 0 {
+		result := searchOutcome{perSource: perSource, totalMS: totalMS, degraded: true}
+		if timeoutOnly {
+			result.retCode = "UPSTREAM_TIMEOUT"
+			result.err = ErrUpstreamTimeout
+			return result
+		}
+		result.retCode = "DEGRADED"
+		result.err = lastErr
+		if negativeCode > 0 {
+			c.cache.Set(ctx, cacheKey, cache.Entry{
+				PerSource: perSource,
+				TotalMS:   totalMS,
+				Degraded:  true,
+				RetCode:   "DEGRADED",
+				Negative:  true,
+			})
+		}
+		return result
 	}
 
-	resp.Items = fuseToContract(c.applyFuse(req.K, result.Items))
-	resp.Timings.CacheHit = false
-	resp.RetCode = "OK"
-	resp.Degraded = false
-
-	c.cache.Set(cacheKey, CacheEntry{
-		Items:     cloneItems(resp.Items),
-		PerSource: cloneTiming(resp.Timings.PerSource),
-		TotalMS:   resp.Timings.TotalMS,
-		Degraded:  resp.Degraded,
-		RetCode:   resp.RetCode,
+	items := fuseToContract(c.applyFuse(ctx, req.K, sourceResults, req.SourceWeights))
+
+	var rewrittenQueries []string
+	if len(subQueries) > 1 {
+		rewrittenQueries = subQueries
+	}
+
+	c.cache.Set(ctx, cacheKey, cache.Entry{
+		Items:            cloneItems(items),
+		PerSource:        cloneTiming(perSource),
+		TotalMS:          totalMS,
+		Degraded:         degraded,
+		RetCode:          "OK",
+		RewrittenQueries: rewrittenQueries,
 	})
 
-	return resp, resp.RetCode, nil
+	return searchOutcome{
+		items:            items,
+		perSource:        perSource,
+		totalMS:          totalMS,
+		degraded:         degraded,
+		retCode:          "OK",
+		rewrittenQueries: rewrittenQueries,
+	}
+}
+
+// activeSources resolves which registered sources req.Sources restricts the
+// fan-out to. An empty allow-list fans out to every registered source.
+func (c *Controller) activeSources(allow []string) []*sourceState {
+	if len(allow) == 0 {
+		return c.sources
+	}
+	active := make([]*sourceState, 0, len(allow))
+	for _, name := range allow {
+		if state, ok := c.byName[name]; ok {
+			active = append(active, state)
+		}
+	}
+	return active
 }
 
-func (c *Controller) callSource(ctx context.Context, req contract.Request) (sources.Result, bool, error) {
+// cacheSourceNames lists active's names in the controller's registration
+// order, for inclusion in the cache key.
+func (c *Controller) cacheSourceNames(active []*sourceState) []string {
+	include := make(map[string]bool, len(active))
+	for _, state := range active {
+		include[state.name] = true
+	}
+	names := make([]string, 0, len(active))
+	for _, state := range c.sources {
+		if include[state.name] {
+			names = append(names, state.name)
+		}
+	}
+	return names
+}
+
+// sourceOutcome carries one source's result from callSources.
+type sourceOutcome struct {
+	name     string
+	result   sources.Result
+	timedOut bool
+	err      error
+}
+
+// callSources fans req out to every state in active concurrently, having
+// first derived a BudgetPlan from ctx's remaining deadline and each
+// source's recent p95 latency so a historically slow source is cancelled
+// before it can consume the whole request budget.
+func (c *Controller) callSources(ctx context.Context, req contract.Request, active []*sourceState) []sourceOutcome {
+	start := time.Now()
+	plan := c.buildBudgetPlan(ctx, req, active)
+	outcomes := make([]sourceOutcome, len(active))
+
+	var wg sync.WaitGroup
+	wg.Add(len(active))
+	for i, state := range active {
+		go func(i int, state *sourceState) {
+			defer wg.Done()
+			p := c.resolvePolicy(state, req.TenantID)
+			result, timedOut, err := state.callSource(ctx, req, plan, start, p)
+			outcomes[i] = sourceOutcome{name: state.name, result: result, timedOut: timedOut, err: err}
+		}(i, state)
+	}
+	wg.Wait()
+
+	return outcomes
+}
+
+// buildBudgetPlan derives a policy.BudgetPlan from ctx's remaining deadline
+// (zero if ctx has none) and every active source's recent p95 latency,
+// resolved for req's tenant so a tenant-scoped policy's own latency history
+// governs its sub-deadline.
+func (c *Controller) buildBudgetPlan(ctx context.Context, req contract.Request, active []*sourceState) policy.BudgetPlan {
+	var overall time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		overall = time.Until(deadline)
+	}
+
+	p95 := make(map[string]time.Duration, len(active))
+	for _, state := range active {
+		p95[state.name] = c.resolvePolicy(state, req.TenantID).LatencyPercentile(0.95)
+	}
+	return policy.DeriveBudgetPlan(overall, p95)
+}
+
+func (s *sourceState) callSource(ctx context.Context, req contract.Request, plan policy.BudgetPlan, start time.Time, p *policy.SourcePolicy) (sources.Result, bool, error) {
+	if deadline, ok := plan.DeadlineFor(s.name, start); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, deadline)
+		defer cancel()
+	}
+
+	if s.distRate != nil {
+		// rlErr only signals that the owning peer was unreachable and the
+		// limiter fell back to strict-local mode; allowed still reflects the
+		// decision that should be enforced.
+		allowed, _ := s.distRate.Allow(ctx, s.rateLimitKey())
+		if !allowed {
+			return sources.Result{}, false, policy.ErrRateLimited
+		}
+	}
+
+	if s.hedge.Enabled && s.hedge.MaxAttempts >= 2 {
+		return s.callSourceHedged(ctx, req, p)
+	}
+	return s.callSourceOnce(ctx, req, p)
+}
+
+// callSourceOnce issues a single attempt against the upstream source, gated
+// by the circuit breaker and rate limiter in p (the tenant-resolved policy
+// for this call, per Controller.resolvePolicy).
+func (s *sourceState) callSourceOnce(ctx context.Context, req contract.Request, p *policy.SourcePolicy) (sources.Result, bool, error) {
 	var result sources.Result
 	var timedOut bool
-	err := c.policy.Execute(ctx, func(callCtx context.Context) error {
+
+	err := p.Execute(ctx, func(callCtx context.Context) error {
 		select {
 		case <-time.After(200 * time.Millisecond):
 		case <-callCtx.Done():
 			timedOut = errors.Is(callCtx.Err(), context.DeadlineExceeded)
 			return callCtx.Err()
 		}
-		result = c.source.Search(callCtx, []sources.Query{
-			{
-				Collection: c.collection,
-				Payload:    c.buildPayload(req.K),
-			},
+		var callErr error
+		result, callErr = s.plugin.Search(callCtx, sources.Query{
+			Collection: s.collection,
+			Payload:    buildPayload(req, s.fuseConfig),
 		})
-		return result.Err
+		return callErr
 	})
 	if err != nil {
 		if errors.Is(err, context.DeadlineExceeded) {
@@ -201,15 +516,83 @@ func (c *Controller) callSource(ctx context.Context, req contract.Request) (sour
 	return result, timedOut, err
 }
 
-func (c *Controller) applyFuse(k int, raw []json.RawMessage) []fuse.FusedItem {
-	if len(raw) == 0 {
-		return nil
+// hedgedAttempt carries the outcome of one attempt launched by
+// callSourceHedged, tagged with its attempt number for metrics.
+type hedgedAttempt struct {
+	attempt  int
+	result   sources.Result
+	timedOut bool
+	err      error
+}
+
+// callSourceHedged races a primary attempt against one or more speculative
+// retries fired while the primary is still outstanding, to bound tail
+// latency. Every attempt still passes through p.Execute, so the circuit
+// breaker and rate limiter gate hedges exactly like the primary call, and
+// all attempts share the parent deadline.
+func (s *sourceState) callSourceHedged(ctx context.Context, req contract.Request, p *policy.SourcePolicy) (sources.Result, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	delay := s.hedge.MinDelay
+	if s.hedge.UsePercentile > 0 {
+		if d := p.LatencyPercentile(s.hedge.UsePercentile); d > 0 {
+			delay = d
+		}
+	}
+	if delay <= 0 {
+		delay = 20 * time.Millisecond
+	}
+	obs.SetHedgeP95(s.name, delay.Milliseconds())
+
+	results := make(chan hedgedAttempt, s.hedge.MaxAttempts)
+	launch := func(n int) {
+		go func() {
+			result, timedOut, err := s.callSourceOnce(ctx, req, p)
+			select {
+			case results <- hedgedAttempt{attempt: n, result: result, timedOut: timedOut, err: err}:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	launch(1)
+	inFlight := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case attempt := <-results:
+			cancel()
+			if attempt.attempt > 1 {
+				obs.IncHedgeWon(s.name, fmt.Sprintf("%d", attempt.attempt))
+			}
+			return attempt.result, attempt.timedOut, attempt.err
+		case <-timer.C:
+			if inFlight >= s.hedge.MaxAttempts {
+				continue
+			}
+			inFlight++
+			obs.IncHedgeFired(s.name)
+			launch(inFlight)
+			timer.Reset(delay)
+		case <-ctx.Done():
+			return sources.Result{}, errors.Is(ctx.Err(), context.DeadlineExceeded), nil
+		}
 	}
-	sourceItems := []fuse.SourceResult{
-		{
-			Source: c.sourceName,
-			Items:  c.decodeItems(raw[0]),
-		},
+}
+
+// rateLimitKey builds the distributed rate-limit key for this source by
+// joining the source name and collection, so every call to the same source
+// and collection shares one key.
+func (s *sourceState) rateLimitKey() string {
+	return s.name + "|" + s.collection
+}
+
+func (c *Controller) applyFuse(ctx context.Context, k int, sourceResults []fuse.SourceResult, sourceWeights map[string]float64) []fuse.FusedItem {
+	if len(sourceResults) == 0 {
+		return nil
 	}
 	cfg := c.fuseConfig
 	if k > cfg.TopKInit {
@@ -219,14 +602,29 @@ func (c *Controller) applyFuse(k int, raw []json.RawMessage) []fuse.FusedItem {
 		cfg.TopKInit = cfg.TopKMax
 	}
 
-	items := fuse.RRFCombine(sourceItems, cfg)
+	var items []fuse.FusedItem
+	if len(sourceWeights) > 0 {
+		cfg.Weights = sourceWeights
+		items = fuse.WeightedCombine(ctx, sourceResults, cfg).Items
+	} else {
+		items = fuse.RRFCombine(ctx, sourceResults, cfg)
+	}
 	if k < len(items) {
 		return items[:k]
 	}
 	return items
 }
 
-func (c *Controller) decodeItems(raw json.RawMessage) []fuse.Item {
+// decodeSourceItems decodes the first raw response in a Plugin's Result,
+// since every call issues exactly one query per source today.
+func decodeSourceItems(raw []json.RawMessage) []fuse.Item {
+	if len(raw) == 0 {
+		return nil
+	}
+	return decodeItems(raw[0])
+}
+
+func decodeItems(raw json.RawMessage) []fuse.Item {
 	if len(raw) == 0 {
 		return nil
 	}
@@ -262,22 +660,79 @@ func (c *Controller) decodeItems(raw json.RawMessage) []fuse.Item {
 	return items
 }
 
-func (c *Controller) buildPayload(k int) any {
-	limit := k
-	if limit < c.fuseConfig.TopKInit {
-		limit = c.fuseConfig.TopKInit
+func buildPayload(req contract.Request, fuseConfig fuse.CombineConfig) any {
+	limit := req.K
+	if limit < fuseConfig.TopKInit {
+		limit = fuseConfig.TopKInit
 	}
-	if limit > c.fuseConfig.TopKMax {
-		limit = c.fuseConfig.TopKMax
+	if limit > fuseConfig.TopKMax {
+		limit = fuseConfig.TopKMax
 	}
 
-	return map[string]any{
+	payload := map[string]any{
 		"limit":         limit,
 		"with_payload":  true,
 		"with_vector":   false,
-		"filter":        nil,
+		"filter":        buildQdrantFilter(req.Filters),
 		"search_params": map[string]any{},
 	}
+	if len(req.Vector) > 0 {
+		payload["vector"] = req.Vector
+		payload["with_vector"] = true
+	}
+	if req.MinScore > 0 {
+		payload["score_threshold"] = req.MinScore
+	}
+	return payload
+}
+
+// buildQdrantFilter translates a contract.FilterGroup into Qdrant's
+// must/should filter DSL. A nil or empty group yields a nil filter.
+func buildQdrantFilter(group *contract.FilterGroup) any {
+	if group == nil || (len(group.Filters) == 0 && len(group.Groups) == 0) {
+		return nil
+	}
+
+	conditions := make([]any, 0, len(group.Filters)+len(group.Groups))
+	for _, f := range group.Filters {
+		conditions = append(conditions, buildQdrantCondition(f))
+	}
+	for i := range group.Groups {
+		if nested := buildQdrantFilter(&group.Groups[i]); nested != nil {
+			conditions = append(conditions, nested)
+		}
+	}
+
+	if group.Combinator == "or" {
+		return map[string]any{"should": conditions}
+	}
+	return map[string]any{"must": conditions}
+}
+
+func buildQdrantCondition(f contract.Filter) any {
+	switch f.Op {
+	case contract.FilterGt, contract.FilterGte, contract.FilterLt, contract.FilterLte:
+		return map[string]any{
+			"key":   f.Field,
+			"range": map[string]any{string(f.Op): f.Value},
+		}
+	case contract.FilterIn:
+		return map[string]any{
+			"key":   f.Field,
+			"match": map[string]any{"any": f.Value},
+		}
+	case contract.FilterNeq:
+		return map[string]any{
+			"must_not": []any{
+				map[string]any{"key": f.Field, "match": map[string]any{"value": f.Value}},
+			},
+		}
+	default: // contract.FilterEq
+		return map[string]any{
+			"key":   f.Field,
+			"match": map[string]any{"value": f.Value},
+		}
+	}
 }
 
 // BuildTraceURL builds a Langfuse trace if configured.
@@ -290,12 +745,19 @@ func (c *Controller) BuildTraceURL(traceID string) string {
 	return fmt.Sprintf("%s/project/%s/traces?query=%s", base, c.project, url.QueryEscape(traceID))
 }
 
-// Ping validates upstream readiness.
+// Ping validates upstream readiness across every registered source,
+// failing if any one of them is unreachable.
 // mvp-5
 func (c *Controller) Ping(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
 	defer cancel()
-	return c.source.Ping(ctx)
+
+	for _, state := range c.sources {
+		if err := state.plugin.HealthCheck(ctx); err != nil {
+			return fmt.Errorf("source %q: %w", state.name, err)
+		}
+	}
+	return nil
 }
 
 func cloneItems(items []contract.Item) []contract.Item {
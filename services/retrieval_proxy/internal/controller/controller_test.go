@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/searchforge/retrieval_proxy/policy"
+	"github.com/searchforge/retrieval_proxy/sources"
+)
+
+// noopPlugin is a minimal sources.Plugin that never gets called in these
+// tests; New requires a non-nil Plugin per binding.
+type noopPlugin struct{}
+
+func (noopPlugin) Search(ctx context.Context, q sources.Query) (sources.Result, error) {
+	return sources.Result{}, nil
+}
+func (noopPlugin) HealthCheck(ctx context.Context) error { return nil }
+func (noopPlugin) Close() error                          { return nil }
+
+func TestResolvePolicyUsesTenantOverride(t *testing.T) {
+	ctrl, err := New(Config{
+		Sources: []SourceBinding{
+			{
+				Name:   "qdrant",
+				Plugin: noopPlugin{},
+				Policy: policy.SourceConfig{
+					Timeout: 50 * time.Millisecond,
+					TenantOverrides: map[string]policy.TenantOverride{
+						"free": {Timeout: 10 * time.Millisecond},
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	state := ctrl.byName["qdrant"]
+
+	if p := ctrl.resolvePolicy(state, ""); p != state.policy {
+		t.Fatal("expected an empty tenantID to fall back to the shared policy")
+	}
+	if p := ctrl.resolvePolicy(state, "enterprise"); p != state.policy {
+		t.Fatal("expected an unconfigured tenant to fall back to the shared policy")
+	}
+	if p := ctrl.resolvePolicy(state, "free"); p == state.policy {
+		t.Fatal("expected a configured tenant to resolve to its own tenant-scoped policy")
+	}
+}
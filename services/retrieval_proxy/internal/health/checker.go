@@ -0,0 +1,32 @@
+package health
+
+import (
+	"context"
+	"time"
+)
+
+// CheckResult reports the outcome of a single dependency check.
+type CheckResult struct {
+	OK        bool
+	LatencyMS int64
+	Err       error
+}
+
+// Checker probes a single upstream dependency (Qdrant, a secondary vector
+// store, Langfuse, ...).
+type Checker interface {
+	Name() string
+	Check(ctx context.Context) CheckResult
+}
+
+// RegisteredCheck pairs a Checker with the readiness policy Readyz should
+// apply to it.
+type RegisteredCheck struct {
+	Checker Checker
+	// Required marks the check as blocking: if it fails, Readyz returns 503.
+	// A failing optional check instead marks the response degraded=true.
+	Required bool
+	// Timeout bounds how long Readyz waits for this check; zero means no
+	// per-check deadline beyond the request context.
+	Timeout time.Duration
+}
@@ -0,0 +1,46 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Livez returns a liveness handler that checks only in-process invariants —
+// the process is scheduling goroutines and the given guards (e.g. "can the
+// cache mutex be acquired") don't deadlock — never an upstream dependency.
+// That distinction matters: a liveness failure should trigger a restart,
+// while a readiness failure should just pull the pod out of rotation.
+func Livez(timeout time.Duration, guards ...func() bool) http.HandlerFunc {
+	if timeout <= 0 {
+		timeout = 50 * time.Millisecond
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		done := make(chan bool, 1)
+		go func() {
+			for _, guard := range guards {
+				if !guard() {
+					done <- false
+					return
+				}
+			}
+			done <- true
+		}()
+
+		select {
+		case ok := <-done:
+			status := http.StatusOK
+			if !ok {
+				status = http.StatusServiceUnavailable
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(map[string]any{"ok": ok})
+		case <-time.After(timeout):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "liveness probe timed out"})
+		}
+	}
+}
@@ -3,30 +3,116 @@ package health
 // mvp-5
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
 	"github.com/searchforge/retrieval_proxy/internal/controller"
 )
 
-// Readyz returns an http.Handler that reports Qdrant readiness.
+var (
+	checkDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "readyz_check_duration_seconds",
+		Help:    "Duration of each readiness check.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"check"})
+	checkUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "readyz_check_up",
+		Help: "Whether a readiness check last passed (1) or failed (0).",
+	}, []string{"check"})
+)
+
+// QdrantChecker adapts Controller.Ping to the Checker interface, the same
+// "reachable in <200ms" semantics Readyz previously hard-coded.
+// mvp-5
+type QdrantChecker struct {
+	ctrl      *controller.Controller
+	threshold time.Duration
+}
+
+// NewQdrantChecker constructs a QdrantChecker that fails if Ping exceeds threshold.
 // mvp-5
-func Readyz(ctrl *controller.Controller) http.HandlerFunc {
+func NewQdrantChecker(ctrl *controller.Controller, threshold time.Duration) *QdrantChecker {
+	if threshold <= 0 {
+		threshold = 200 * time.Millisecond
+	}
+	return &QdrantChecker{ctrl: ctrl, threshold: threshold}
+}
+
+// Name identifies this checker in readyz output and metrics.
+func (c *QdrantChecker) Name() string { return "qdrant" }
+
+// Check pings Qdrant and reports whether it responded within threshold.
+func (c *QdrantChecker) Check(ctx context.Context) CheckResult {
+	start := time.Now()
+	err := c.ctrl.Ping(ctx)
+	latency := time.Since(start)
+	return CheckResult{
+		OK:        err == nil && latency <= c.threshold,
+		LatencyMS: latency.Milliseconds(),
+		Err:       err,
+	}
+}
+
+// Readyz builds a readiness handler from a registry of dependency checks.
+// The response is 503 if any required check fails, and 200 with
+// degraded=true if only optional checks fail, matching the DEGRADED
+// semantics used elsewhere in Controller.Search.
+// mvp-5
+func Readyz(checks ...RegisteredCheck) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		start := time.Now()
-		err := ctrl.Ping(r.Context())
-		latency := time.Since(start)
+		overallOK := true
+		degraded := false
+		results := make(map[string]any, len(checks))
+
+		for _, rc := range checks {
+			ctx := r.Context()
+			if rc.Timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, rc.Timeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			res := rc.Checker.Check(ctx)
+			checkDuration.WithLabelValues(rc.Checker.Name()).Observe(time.Since(start).Seconds())
+
+			upValue := 0.0
+			if res.OK {
+				upValue = 1
+			}
+			checkUp.WithLabelValues(rc.Checker.Name()).Set(upValue)
+
+			entry := map[string]any{
+				"ok":         res.OK,
+				"latency_ms": res.LatencyMS,
+			}
+			if res.Err != nil {
+				entry["last_error"] = res.Err.Error()
+			}
+			results[rc.Checker.Name()] = entry
+
+			if !res.OK {
+				if rc.Required {
+					overallOK = false
+				} else {
+					degraded = true
+				}
+			}
+		}
 
-		ok := err == nil && latency <= 200*time.Millisecond
 		status := http.StatusOK
-		if !ok {
+		if !overallOK {
 			status = http.StatusServiceUnavailable
 		}
 
 		payload := map[string]any{
-			"qdrant_ok":    err == nil,
-			"last_ping_ms": latency.Milliseconds(),
+			"checks":   results,
+			"degraded": degraded,
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -34,4 +120,3 @@ func Readyz(ctrl *controller.Controller) http.HandlerFunc {
 		_ = json.NewEncoder(w).Encode(payload)
 	}
 }
-
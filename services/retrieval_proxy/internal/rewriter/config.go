@@ -0,0 +1,35 @@
+package rewriter
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfig reads a Config from a YAML file, e.g.:
+//
+//	normalize:
+//	  enabled: true
+//	synonyms:
+//	  enabled: true
+//	  max_expansions: 3
+//	  dictionary:
+//	    car: [automobile, vehicle]
+//	hyde:
+//	  enabled: false
+//	multi_query:
+//	  enabled: false
+//	  n: 3
+func LoadConfig(path string) (Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("read rewriter config: %w", err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parse rewriter config: %w", err)
+	}
+	return cfg, nil
+}
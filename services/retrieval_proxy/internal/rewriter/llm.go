@@ -0,0 +1,90 @@
+package rewriter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// LLMClient generates text from a prompt, used by the HyDE and MultiQuery
+// stages. Complete asks for a single completion; CompleteN asks for n
+// independent ones.
+type LLMClient interface {
+	Complete(ctx context.Context, prompt string) (string, error)
+	CompleteN(ctx context.Context, prompt string, n int) ([]string, error)
+}
+
+// HTTPLLMClient calls a JSON HTTP completion endpoint: it POSTs
+// {"prompt": ..., "n": ...} and expects {"completions": [...]} back.
+type HTTPLLMClient struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewHTTPLLMClient builds an HTTPLLMClient targeting endpoint, with
+// requests bounded by timeout.
+func NewHTTPLLMClient(endpoint string, timeout time.Duration) *HTTPLLMClient {
+	return &HTTPLLMClient{
+		endpoint: endpoint,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+type completionRequest struct {
+	Prompt string `json:"prompt"`
+	N      int    `json:"n"`
+}
+
+type completionResponse struct {
+	Completions []string `json:"completions"`
+}
+
+// Complete implements LLMClient.
+func (c *HTTPLLMClient) Complete(ctx context.Context, prompt string) (string, error) {
+	completions, err := c.CompleteN(ctx, prompt, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(completions) == 0 {
+		return "", fmt.Errorf("llm endpoint returned no completions")
+	}
+	return completions[0], nil
+}
+
+// CompleteN implements LLMClient.
+func (c *HTTPLLMClient) CompleteN(ctx context.Context, prompt string, n int) ([]string, error) {
+	body, err := json.Marshal(completionRequest{Prompt: prompt, N: n})
+	if err != nil {
+		return nil, fmt.Errorf("marshal completion request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("create completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("llm request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read completion response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("llm endpoint status %d", resp.StatusCode)
+	}
+
+	var out completionResponse
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("parse completion response: %w", err)
+	}
+	return out.Completions, nil
+}
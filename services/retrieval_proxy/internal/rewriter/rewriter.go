@@ -0,0 +1,169 @@
+// Package rewriter transforms a raw query into one or more enriched
+// sub-queries before the controller fans out to sources: lowercase/
+// whitespace normalization, dictionary-driven synonym expansion, optional
+// HyDE (hypothetical document embeddings), and optional multi-query
+// paraphrase generation. Each stage is independently enabled via Config, so
+// the zero Config is a pure passthrough.
+package rewriter
+
+import (
+	"context"
+	"strings"
+)
+
+// Config enables and parametrizes each pipeline stage.
+type Config struct {
+	Normalize  NormalizeConfig  `yaml:"normalize"`
+	Synonyms   SynonymConfig    `yaml:"synonyms"`
+	HyDE       HyDEConfig       `yaml:"hyde"`
+	MultiQuery MultiQueryConfig `yaml:"multi_query"`
+}
+
+// NormalizeConfig controls stage 1: lowercasing and whitespace collapsing.
+type NormalizeConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// SynonymConfig controls stage 2: expanding terms found in Dictionary into
+// additional sub-queries with that term substituted.
+type SynonymConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Dictionary maps a term to the synonyms that should each generate an
+	// additional sub-query when the term appears in the query.
+	Dictionary map[string][]string `yaml:"dictionary"`
+	// MaxExpansions bounds how many synonym-substituted sub-queries are
+	// generated, so a term with many synonyms can't blow up fan-out.
+	// Defaults to 3 when unset.
+	MaxExpansions int `yaml:"max_expansions"`
+}
+
+// HyDEConfig controls stage 3: generating a hypothetical answer to the
+// query via llm and using it as an additional sub-query, on the premise
+// that the answer's embedding sits closer to relevant documents than the
+// question's embedding does.
+type HyDEConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// MultiQueryConfig controls stage 4: generating N paraphrases of the query
+// via llm, each dispatched as its own sub-query.
+type MultiQueryConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// N is how many paraphrases to request. Defaults to 3 when unset.
+	N int `yaml:"n"`
+}
+
+// Pipeline runs the configured stages in order. A nil *Pipeline is a valid
+// passthrough, so callers that don't configure rewriting need no nil check.
+type Pipeline struct {
+	cfg Config
+	llm LLMClient
+}
+
+// New constructs a Pipeline. llm may be nil when neither HyDE nor
+// MultiQuery is enabled.
+func New(cfg Config, llm LLMClient) *Pipeline {
+	return &Pipeline{cfg: cfg, llm: llm}
+}
+
+// Rewrite runs query through every enabled stage and returns the set of
+// sub-queries that should each become a separate sources.Query, in
+// dispatch order. The (possibly normalized) original query is always
+// first. A stage that errors (e.g. an unreachable HyDE/MultiQuery
+// endpoint) is skipped rather than failing the whole search, since a
+// rewritten query is an enrichment, not a prerequisite.
+func (p *Pipeline) Rewrite(ctx context.Context, query string) []string {
+	if p == nil {
+		return []string{query}
+	}
+
+	base := query
+	if p.cfg.Normalize.Enabled {
+		base = normalize(base)
+	}
+
+	queries := []string{base}
+
+	if p.cfg.Synonyms.Enabled {
+		queries = append(queries, expandSynonyms(base, p.cfg.Synonyms)...)
+	}
+
+	if p.cfg.HyDE.Enabled && p.llm != nil {
+		if answer, err := p.llm.Complete(ctx, hydePrompt(base)); err == nil && answer != "" {
+			queries = append(queries, answer)
+		}
+	}
+
+	if p.cfg.MultiQuery.Enabled && p.llm != nil {
+		n := p.cfg.MultiQuery.N
+		if n <= 0 {
+			n = 3
+		}
+		if paraphrases, err := p.llm.CompleteN(ctx, multiQueryPrompt(base), n); err == nil {
+			queries = append(queries, paraphrases...)
+		}
+	}
+
+	return dedupe(queries)
+}
+
+// normalize lowercases query and collapses runs of whitespace to a single
+// space, mirroring the normalizeQuery helpers already used at the API and
+// cache layers.
+func normalize(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// expandSynonyms substitutes, one token at a time, every token in query
+// that has a Dictionary entry, producing up to cfg.MaxExpansions
+// additional sub-queries.
+func expandSynonyms(query string, cfg SynonymConfig) []string {
+	if len(cfg.Dictionary) == 0 {
+		return nil
+	}
+
+	maxExpansions := cfg.MaxExpansions
+	if maxExpansions <= 0 {
+		maxExpansions = 3
+	}
+
+	tokens := strings.Fields(query)
+	var expansions []string
+	for i, tok := range tokens {
+		synonyms, ok := cfg.Dictionary[tok]
+		if !ok {
+			continue
+		}
+		for _, syn := range synonyms {
+			if len(expansions) >= maxExpansions {
+				return expansions
+			}
+			variant := append([]string(nil), tokens...)
+			variant[i] = syn
+			expansions = append(expansions, strings.Join(variant, " "))
+		}
+	}
+	return expansions
+}
+
+func hydePrompt(query string) string {
+	return "Write a short hypothetical passage that would answer the question: " + query
+}
+
+func multiQueryPrompt(query string) string {
+	return "Generate alternative phrasings of this search query: " + query
+}
+
+// dedupe drops empty and repeated entries, preserving first-seen order.
+func dedupe(queries []string) []string {
+	seen := make(map[string]bool, len(queries))
+	out := make([]string, 0, len(queries))
+	for _, q := range queries {
+		if q == "" || seen[q] {
+			continue
+		}
+		seen[q] = true
+		out = append(out, q)
+	}
+	return out
+}
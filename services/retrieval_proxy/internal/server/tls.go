@@ -0,0 +1,145 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// TLSConfig configures the TLS parameters honored by ListenAndServeTLS. It
+// lets operators in regulated environments pin a minimum protocol version
+// and an allow-listed cipher suite set for both /readyz and /search.
+type TLSConfig struct {
+	MinVersion   string
+	CipherSuites []string
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   string
+
+	// AllowInsecureCiphers permits cipher suite names that crypto/tls marks
+	// insecure by default (tls.InsecureCipherSuites).
+	AllowInsecureCiphers bool
+}
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthModes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify_if_given":    tls.VerifyClientCertIfGiven,
+	"require_and_verify": tls.RequireAndVerifyClientCert,
+}
+
+// Build resolves cfg into a *tls.Config, rejecting unknown or
+// insecure-by-default cipher suite names unless AllowInsecureCiphers is set.
+func (cfg TLSConfig) Build() (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, fmt.Errorf("tls: cert_file and key_file are required")
+	}
+
+	minVersion, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		if cfg.MinVersion == "" {
+			minVersion = tls.VersionTLS12
+		} else {
+			return nil, fmt.Errorf("tls: unknown min_version %q (want \"1.2\" or \"1.3\")", cfg.MinVersion)
+		}
+	}
+
+	suites, err := resolveCipherSuites(cfg.CipherSuites, cfg.AllowInsecureCiphers)
+	if err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.NoClientCert
+	if cfg.ClientAuth != "" {
+		clientAuth, ok = clientAuthModes[cfg.ClientAuth]
+		if !ok {
+			return nil, fmt.Errorf("tls: unknown client_auth %q", cfg.ClientAuth)
+		}
+	}
+
+	tlsCfg := &tls.Config{
+		MinVersion:   minVersion,
+		CipherSuites: suites,
+		ClientAuth:   clientAuth,
+	}
+
+	if cfg.ClientCAFile != "" {
+		pool, err := loadCertPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: client CA: %w", err)
+		}
+		tlsCfg.ClientCAs = pool
+	}
+
+	return tlsCfg, nil
+}
+
+func resolveCipherSuites(names []string, allowInsecure bool) ([]uint16, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	secure := make(map[string]uint16, len(tls.CipherSuites()))
+	for _, s := range tls.CipherSuites() {
+		secure[s.Name] = s.ID
+	}
+	insecure := make(map[string]uint16, len(tls.InsecureCipherSuites()))
+	for _, s := range tls.InsecureCipherSuites() {
+		insecure[s.Name] = s.ID
+	}
+
+	ids := make([]uint16, 0, len(names))
+	for _, raw := range names {
+		name := strings.TrimSpace(raw)
+		if id, ok := secure[name]; ok {
+			ids = append(ids, id)
+			continue
+		}
+		if id, ok := insecure[name]; ok {
+			if !allowInsecure {
+				return nil, fmt.Errorf("tls: cipher suite %q is insecure by default; set allow_insecure_ciphers to permit it", name)
+			}
+			ids = append(ids, id)
+			continue
+		}
+		return nil, fmt.Errorf("tls: unknown cipher suite %q", name)
+	}
+	return ids, nil
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(raw) {
+		return nil, fmt.Errorf("no certificates found in %s", path)
+	}
+	return pool, nil
+}
+
+// ListenAndServeTLS serves handler on addr using the TLS parameters in cfg.
+func ListenAndServeTLS(addr string, handler http.Handler, cfg TLSConfig) error {
+	tlsCfg, err := cfg.Build()
+	if err != nil {
+		return err
+	}
+
+	srv := &http.Server{
+		Addr:      addr,
+		Handler:   handler,
+		TLSConfig: tlsCfg,
+	}
+	return srv.ListenAndServeTLS(cfg.CertFile, cfg.KeyFile)
+}
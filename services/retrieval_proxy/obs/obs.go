@@ -12,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -25,8 +26,8 @@ var (
 var (
 	proxyRequests = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "retrieval_proxy_requests_total",
-		Help: "Total proxy requests by return code.",
-	}, []string{"code"})
+		Help: "Total proxy requests by return code and tenant.",
+	}, []string{"code", "tenant"})
 	proxyDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "retrieval_proxy_request_duration_ms",
 		Help:    "Histogram of proxy request latency in ms.",
@@ -39,22 +40,66 @@ var (
 	}, []string{"source"})
 	sourceErrors = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "retrieval_proxy_source_errors_total",
-		Help: "Count of upstream errors grouped by source and code.",
-	}, []string{"source", "code"})
+		Help: "Count of upstream errors grouped by source, code, and tenant.",
+	}, []string{"source", "code", "tenant"})
 	budgetHits = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "retrieval_proxy_budget_hit_total",
 		Help: "Total requests that exhausted the configured budget.",
 	})
+	budgetHitSources = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_budget_hit_sources_total",
+		Help: "Count of source calls that ended on a deadline, by source.",
+	}, []string{"source"})
 	circuitStates = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "retrieval_proxy_circuit_state",
 		Help: "Circuit breaker state per source (0=closed,1=half-open,2=open).",
 	}, []string{"source", "state"})
+	policyRLDegraded = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_policy_rl_degraded_total",
+		Help: "Count of distributed rate-limit decisions that fell back to strict-local mode.",
+	}, []string{"source"})
+	hedgeFired = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_hedge_fired_total",
+		Help: "Count of hedged (speculative) source requests fired.",
+	}, []string{"source"})
+	hedgeWon = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_hedge_won_total",
+		Help: "Count of hedged source requests grouped by which attempt won.",
+	}, []string{"source", "attempt"})
+	hedgeP95 = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "retrieval_proxy_hedge_p95_ms",
+		Help: "Current p95 latency estimate used to trigger hedged requests.",
+	}, []string{"source"})
+	sourceErrorClass = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_source_error_class_total",
+		Help: "Count of upstream source call outcomes grouped by classified error type.",
+	}, []string{"source", "class"})
+	sourceEffectiveTimeout = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "retrieval_proxy_source_effective_timeout_ms",
+		Help: "Effective per-call timeout currently in use for a source, after adaptive adjustment.",
+	}, []string{"source"})
+	sourceHedged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_source_hedged_total",
+		Help: "Count of hedged SourcePolicy.Execute calls grouped by which attempt won, if any.",
+	}, []string{"source", "outcome"})
+	cacheHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_cache_hits_total",
+		Help: "Count of response cache hits grouped by backend tier.",
+	}, []string{"tier"})
+	cacheSingleflightCoalesced = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "retrieval_proxy_cache_singleflight_coalesced_total",
+		Help: "Count of concurrent identical requests coalesced onto a single upstream fan-out.",
+	})
+	sourceHedge = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "retrieval_proxy_source_hedge_total",
+		Help: "Count of QdrantSource hedged query attempts grouped by source and which attempt won.",
+	}, []string{"source", "winner"})
 )
 
 // ObserveProxyRequest records proxy-level metrics.
 // mvp-5
-func ObserveProxyRequest(code string, duration time.Duration, traceID string) {
-	proxyRequests.WithLabelValues(code).Inc()
+func ObserveProxyRequest(code string, duration time.Duration, traceID, tenant string) {
+	proxyRequests.WithLabelValues(code, tenant).Inc()
 	if eo, ok := proxyDuration.(prometheus.ExemplarObserver); ok && traceID != "" {
 		eo.ObserveWithExemplar(
 			float64(duration.Milliseconds()),
@@ -65,16 +110,27 @@ func ObserveProxyRequest(code string, duration time.Duration, traceID string) {
 	proxyDuration.Observe(float64(duration.Milliseconds()))
 }
 
-// RecordSourceDuration observes the latency for a source.
+// RecordSourceDuration observes the latency for a source, attaching an
+// exemplar pointing at traceID so the histogram bucket that recorded this
+// call can be traced back to its distributed trace in Grafana/Tempo.
 // mvp-5
-func RecordSourceDuration(source string, duration time.Duration) {
-	sourceDuration.WithLabelValues(source).Observe(float64(duration.Milliseconds()))
+func RecordSourceDuration(source string, duration time.Duration, traceID string) {
+	hist := sourceDuration.WithLabelValues(source)
+	if eo, ok := hist.(prometheus.ExemplarObserver); ok && traceID != "" {
+		eo.ObserveWithExemplar(
+			float64(duration.Milliseconds()),
+			prometheus.Labels{"trace_id": traceID},
+		)
+		return
+	}
+	hist.Observe(float64(duration.Milliseconds()))
 }
 
-// RecordSourceError increments the error counter for a source/code combination.
+// RecordSourceError increments the error counter for a source/code/tenant
+// combination. tenant is "" when the call was not tenant-scoped.
 // mvp-5
-func RecordSourceError(source, code string) {
-	sourceErrors.WithLabelValues(source, code).Inc()
+func RecordSourceError(source, code, tenant string) {
+	sourceErrors.WithLabelValues(source, code, tenant).Inc()
 }
 
 // IncBudgetHit records a budget exhaustion event.
@@ -83,6 +139,14 @@ func IncBudgetHit() {
 	budgetHits.Inc()
 }
 
+// IncBudgetHitSource records that a call to source ended on a deadline. The
+// count does not tell apart its per-source budget-derived deadline, the
+// overall request budget, and the source's own timeout.
+// mvp-5
+func IncBudgetHitSource(source string) {
+	budgetHitSources.WithLabelValues(source).Inc()
+}
+
 // SetCircuitState updates the gauge representing circuit breaker state.
 // mvp-5
 func SetCircuitState(source, state string) {
@@ -99,6 +163,78 @@ func SetCircuitState(source, state string) {
 	circuitStates.WithLabelValues(source, state).Set(value)
 }
 
+// IncPolicyRateLimitDegraded records a distributed rate limiter falling back
+// to strict-local mode because the owning peer was unreachable.
+// mvp-5
+func IncPolicyRateLimitDegraded(source string) {
+	policyRLDegraded.WithLabelValues(source).Inc()
+}
+
+// IncHedgeFired records a hedged (speculative) attempt being fired for source.
+// mvp-5
+func IncHedgeFired(source string) {
+	hedgeFired.WithLabelValues(source).Inc()
+}
+
+// IncHedgeWon records which attempt (e.g. "1", "2") produced the winning
+// response for a hedged call.
+// mvp-5
+func IncHedgeWon(source, attempt string) {
+	hedgeWon.WithLabelValues(source, attempt).Inc()
+}
+
+// SetHedgeP95 records the current p95 latency estimate driving the hedge trigger.
+// mvp-5
+func SetHedgeP95(source string, ms int64) {
+	hedgeP95.WithLabelValues(source).Set(float64(ms))
+}
+
+// IncSourceErrorClass records a source call outcome under its classified
+// error type (e.g. "timeout", "canceled", "rate_limited"), driving the
+// circuit breaker's per-class failure-rate accounting.
+// mvp-5
+func IncSourceErrorClass(source, class string) {
+	sourceErrorClass.WithLabelValues(source, class).Inc()
+}
+
+// SetEffectiveTimeout records the timeout currently applied to calls for a
+// source, reflecting any adaptive adjustment.
+// mvp-5
+func SetEffectiveTimeout(source string, ms int64) {
+	sourceEffectiveTimeout.WithLabelValues(source).Set(float64(ms))
+}
+
+// IncSourceHedged records the outcome of a hedged SourcePolicy.Execute call:
+// "winner_primary" when the first attempt wins, "winner_hedge" when a
+// speculative retry wins, or "both_failed" when every attempt failed.
+// mvp-5
+func IncSourceHedged(source, outcome string) {
+	sourceHedged.WithLabelValues(source, outcome).Inc()
+}
+
+// IncCacheHit records a response cache hit served from the named backend
+// tier (e.g. "memory", "redis").
+// mvp-5
+func IncCacheHit(tier string) {
+	cacheHits.WithLabelValues(tier).Inc()
+}
+
+// IncCacheSingleflightCoalesced records a concurrent request that was
+// coalesced onto an in-flight identical upstream fan-out instead of
+// triggering its own.
+// mvp-5
+func IncCacheSingleflightCoalesced() {
+	cacheSingleflightCoalesced.Inc()
+}
+
+// IncSourceHedge records the outcome of a QdrantSource hedged query: winner
+// is the attempt number ("1", "2", ...) that returned first without error,
+// or "both_failed" when every attempt failed.
+// mvp-5
+func IncSourceHedge(source, winner string) {
+	sourceHedge.WithLabelValues(source, winner).Inc()
+}
+
 // InitTracer sets up a minimal OpenTelemetry tracer provider.
 // mvp-5
 func InitTracer(serviceName string) (func(context.Context) error, error) {
@@ -119,6 +255,7 @@ func InitTracer(serviceName string) (func(context.Context) error, error) {
 			sdktrace.WithResource(res),
 		)
 		otel.SetTracerProvider(provider)
+		otel.SetTextMapPropagator(propagation.TraceContext{})
 		shutdown = provider.Shutdown
 	})
 	return shutdown, initErr
@@ -9,17 +9,38 @@ import (
 	"time"
 )
 
-func ObserveProxyRequest(string, time.Duration, string) {}
+func ObserveProxyRequest(string, time.Duration, string, string) {}
 
-func RecordSourceDuration(string, time.Duration) {}
+func RecordSourceDuration(string, time.Duration, string) {}
 
-func RecordSourceError(string, string) {}
+func RecordSourceError(string, string, string) {}
 
 func IncBudgetHit() {}
 
+func IncBudgetHitSource(string) {}
+
 func SetCircuitState(string, string) {}
 
+func IncPolicyRateLimitDegraded(string) {}
+
+func IncHedgeFired(string) {}
+
+func IncHedgeWon(string, string) {}
+
+func SetHedgeP95(string, int64) {}
+
+func IncSourceErrorClass(string, string) {}
+
+func SetEffectiveTimeout(string, int64) {}
+
+func IncSourceHedged(string, string) {}
+
+func IncCacheHit(string) {}
+
+func IncCacheSingleflightCoalesced() {}
+
+func IncSourceHedge(string, string) {}
+
 func InitTracer(string) (func(context.Context) error, error) {
 	return func(context.Context) error { return nil }, nil
 }
-
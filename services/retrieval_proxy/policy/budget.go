@@ -4,9 +4,15 @@ package policy
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"github.com/searchforge/retrieval_proxy/obs"
 )
 
@@ -25,20 +31,148 @@ func (b *BudgetResult) Hit() bool {
 	return b.hit.Load()
 }
 
-// BudgetArbiter derives a deadline-bound context from parent and records whether the budget was reached.
+// RetryPolicy configures the capped exponential backoff BudgetArbiter.Execute
+// applies to retryable per-source errors.
+type RetryPolicy struct {
+	// Initial is the delay before the first retry.
+	Initial time.Duration
+	// Max caps the delay between retries; it is never exceeded even after
+	// repeated doubling.
+	Max time.Duration
+	// Multiplier scales the delay after each retry; values <= 1 fall back
+	// to DefaultRetryPolicy.Multiplier.
+	Multiplier float64
+	// Classifier reports whether err is worth retrying. A nil Classifier
+	// falls back to DefaultRetryPolicy.Classifier.
+	Classifier func(error) bool
+}
+
+// DefaultRetryPolicy is a gax-style capped exponential backoff: an initial
+// 100ms delay, doubling up to a 1s cap, retrying context.DeadlineExceeded
+// (from a per-attempt sub-context, not the overall budget) and the gRPC
+// Unavailable/ResourceExhausted codes.
+var DefaultRetryPolicy = RetryPolicy{
+	Initial:    100 * time.Millisecond,
+	Max:        time.Second,
+	Multiplier: 2,
+	Classifier: defaultRetryClassifier,
+}
+
+func defaultRetryClassifier(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+// BudgetArbiterOption customizes a BudgetArbiter built by NewBudgetArbiter.
+type BudgetArbiterOption func(*budgetArbiterConfig)
+
+type budgetArbiterConfig struct {
+	retry         RetryPolicy
+	breaker       CircuitBreakerConfig
+	hedgeDelay    time.Duration
+	hedgeQuantile float64
+}
+
+// defaultHedgeDelay is the fallback trigger delay BudgetArbiter.Hedge uses
+// for a source until it has enough latency history for hedgeQuantile to
+// produce an estimate.
+const defaultHedgeDelay = 20 * time.Millisecond
+
+// defaultHedgeQuantile is the quantile of a source's own recently observed
+// latency BudgetArbiter.Hedge uses to derive its trigger delay.
+const defaultHedgeQuantile = 0.95
+
+// WithRetryPolicy overrides the backoff and retryable-error classifier used
+// by BudgetArbiter.Execute. The default is DefaultRetryPolicy.
+func WithRetryPolicy(rp RetryPolicy) BudgetArbiterOption {
+	return func(cfg *budgetArbiterConfig) {
+		cfg.retry = rp
+	}
+}
+
+// WithBreakerCooldown overrides the cooldown each per-source circuit
+// breaker spends Open before probing Half-Open again. The default matches
+// NewSourcePolicy's: 2s.
+func WithBreakerCooldown(d time.Duration) BudgetArbiterOption {
+	return func(cfg *budgetArbiterConfig) {
+		cfg.breaker.Cooldown = d
+	}
+}
+
+// WithHedgeDelay overrides the fixed delay BudgetArbiter.Hedge waits before
+// firing the next source when the current one lacks enough latency history
+// to derive a quantile-based delay. The default is 20ms.
+func WithHedgeDelay(d time.Duration) BudgetArbiterOption {
+	return func(cfg *budgetArbiterConfig) {
+		cfg.hedgeDelay = d
+	}
+}
+
+// WithHedgeQuantile overrides the quantile (0, 1] of a source's own recent
+// latency history BudgetArbiter.Hedge uses to derive its trigger delay once
+// that history has enough samples. The default is 0.95 (p95).
+func WithHedgeQuantile(p float64) BudgetArbiterOption {
+	return func(cfg *budgetArbiterConfig) {
+		cfg.hedgeQuantile = p
+	}
+}
+
+// BudgetArbiter derives a deadline-bound context from the overall request
+// budget, tracks whether that budget was exhausted, and retries individual
+// per-source calls made through Execute with a capped exponential backoff.
 // mvp-5
-func BudgetArbiter(parent context.Context, budgetMS int) (context.Context, context.CancelFunc, *BudgetResult) {
+type BudgetArbiter struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	result  *BudgetResult
+	metrics *Metrics
+	retry   RetryPolicy
+
+	breakerCfg CircuitBreakerConfig
+	breakersMu sync.Mutex
+	breakers   map[string]*CircuitBreaker
+
+	hedgeDelay    time.Duration
+	hedgeQuantile float64
+	latenciesMu   sync.Mutex
+	latencies     map[string]*LatencyReservoir
+}
+
+// NewBudgetArbiter derives a deadline-bound context from parent per
+// budgetMS (0 means no deadline) and returns a BudgetArbiter wrapping it.
+// It returns ErrInvalidBudget if budgetMS is negative. metrics may be nil,
+// in which case Execute's per-attempt observations are simply dropped.
+func NewBudgetArbiter(parent context.Context, budgetMS int, metrics *Metrics, opts ...BudgetArbiterOption) (*BudgetArbiter, error) {
+	if budgetMS < 0 {
+		return nil, errInvalidBudget
+	}
 	if parent == nil {
 		parent = context.Background()
 	}
 
+	cfg := budgetArbiterConfig{retry: DefaultRetryPolicy, hedgeDelay: defaultHedgeDelay, hedgeQuantile: defaultHedgeQuantile}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
 	result := &BudgetResult{}
-	if budgetMS <= 0 {
-		ctx, cancel := context.WithCancel(parent)
-		return ctx, cancel, result
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if budgetMS == 0 {
+		ctx, cancel = context.WithCancel(parent)
+	} else {
+		ctx, cancel = context.WithTimeout(parent, time.Duration(budgetMS)*time.Millisecond)
 	}
 
-	ctx, cancel := context.WithTimeout(parent, time.Duration(budgetMS)*time.Millisecond)
 	go func() {
 		<-ctx.Done()
 		if ctx.Err() == context.DeadlineExceeded {
@@ -46,5 +180,297 @@ func BudgetArbiter(parent context.Context, budgetMS int) (context.Context, conte
 			obs.IncBudgetHit()
 		}
 	}()
-	return ctx, cancel, result
+
+	return &BudgetArbiter{
+		ctx:           ctx,
+		cancel:        cancel,
+		result:        result,
+		metrics:       metrics,
+		retry:         cfg.retry,
+		breakerCfg:    defaultCircuitBreakerConfig(cfg.breaker),
+		breakers:      make(map[string]*CircuitBreaker),
+		hedgeDelay:    cfg.hedgeDelay,
+		hedgeQuantile: cfg.hedgeQuantile,
+		latencies:     make(map[string]*LatencyReservoir),
+	}, nil
+}
+
+// breakerFor returns the per-source CircuitBreaker for source, creating and
+// caching one on first use so every Execute call for that source shares the
+// same rolling window and state.
+func (a *BudgetArbiter) breakerFor(source string) *CircuitBreaker {
+	a.breakersMu.Lock()
+	defer a.breakersMu.Unlock()
+
+	if cb, ok := a.breakers[source]; ok {
+		return cb
+	}
+	cb := NewCircuitBreaker(source, a.breakerCfg, a.metrics)
+	a.breakers[source] = cb
+	return cb
+}
+
+// Context returns the deadline-bound context derived from the overall
+// request budget.
+func (a *BudgetArbiter) Context() context.Context {
+	return a.ctx
+}
+
+// Cancel releases resources associated with the arbiter's context. Callers
+// should defer it immediately after NewBudgetArbiter, same as
+// context.WithTimeout's CancelFunc.
+func (a *BudgetArbiter) Cancel() {
+	a.cancel()
+}
+
+// Result returns the BudgetResult tracking whether the overall budget was
+// exhausted.
+func (a *BudgetArbiter) Result() *BudgetResult {
+	return a.result
+}
+
+// Execute calls fn, retrying with a's RetryPolicy backoff as long as the
+// error is retryable per the policy's Classifier and the overall budget
+// context has not yet expired. source labels each attempt's
+// Metrics.ObserveSource observation, so exhausted-budget calls (context
+// canceled, no further attempts) are distinguishable on a dashboard from
+// exhausted-retries calls (classifier kept approving retries until a
+// non-retryable or final error came back).
+//
+// Once the budget context expires, Execute gives up and returns
+// ErrBudgetExceeded wrapping the last attempt's error as its cause, so
+// callers can branch on errors.As(err, &netErr).Timeout() uniformly with
+// any other timeout, while errors.Unwrap still reaches the underlying
+// cause.
+//
+// Every source gets its own CircuitBreaker, lazily created on first use and
+// shared across calls. Once that breaker is Open, Execute short-circuits to
+// ErrCircuitOpen without calling fn or spending any retry backoff; in
+// Half-Open it lets through up to the breaker's configured probe calls, per
+// CircuitBreaker.Allow.
+func (a *BudgetArbiter) Execute(source string, fn func(context.Context) error) error {
+	delay := a.retry.Initial
+	if delay <= 0 {
+		delay = DefaultRetryPolicy.Initial
+	}
+	maxDelay := a.retry.Max
+	if maxDelay <= 0 {
+		maxDelay = DefaultRetryPolicy.Max
+	}
+	multiplier := a.retry.Multiplier
+	if multiplier <= 1 {
+		multiplier = DefaultRetryPolicy.Multiplier
+	}
+	classifier := a.retry.Classifier
+	if classifier == nil {
+		classifier = DefaultRetryPolicy.Classifier
+	}
+
+	breaker := a.breakerFor(source)
+
+	var lastErr error
+	for {
+		now := time.Now()
+		if !breaker.Allow(now) {
+			return ErrCircuitOpen
+		}
+
+		err := fn(a.ctx)
+		duration := time.Since(now)
+		a.metrics.ObserveSource(source, duration, err)
+		a.latencyFor(source).Observe(duration)
+		breaker.Record(time.Now(), err == nil, classifyError(err))
+
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+
+		if a.ctx.Err() != nil {
+			return errBudgetExceeded.withCause(lastErr)
+		}
+		if !classifier(err) {
+			return lastErr
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-a.ctx.Done():
+			timer.Stop()
+			return errBudgetExceeded.withCause(lastErr)
+		case <-timer.C:
+		}
+
+		delay = time.Duration(float64(delay) * multiplier)
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+// latencyFor returns the per-source LatencyReservoir Hedge uses to derive
+// trigger delays, creating and caching one on first use so every Hedge (and
+// Execute) call for that source feeds the same history.
+func (a *BudgetArbiter) latencyFor(source string) *LatencyReservoir {
+	a.latenciesMu.Lock()
+	defer a.latenciesMu.Unlock()
+
+	if r, ok := a.latencies[source]; ok {
+		return r
+	}
+	r := NewLatencyReservoir(0)
+	a.latencies[source] = r
+	return r
+}
+
+// hedgeDelayFor returns the delay Hedge waits before firing the attempt
+// against source: the configured quantile of that source's own recent
+// latency once it has enough samples, or a's fixed hedgeDelay otherwise.
+func (a *BudgetArbiter) hedgeDelayFor(source string) time.Duration {
+	if p := a.latencyFor(source).Percentile(a.hedgeQuantile); p > 0 {
+		obs.SetHedgeP95(source, p.Milliseconds())
+		return p
+	}
+	return a.hedgeDelay
+}
+
+// HedgeResult records the outcome of a BudgetArbiter.Hedge call.
+type HedgeResult struct {
+	// Source is the name of the source whose call won.
+	Source string
+	// Hedges is the number of speculative attempts fired after the
+	// primary before a winner was found.
+	Hedges int
+}
+
+type hedgeAttempt struct {
+	source  string
+	attempt int
+	err     error
+}
+
+// Hedge races fn across sources in order: sources[0] fires immediately as
+// the primary, and each subsequent source fires once the delay
+// hedgeDelayFor derives for that source elapses without any attempt having
+// succeeded yet; an attempt that fails does not bring the next one forward. The
+// first attempt to return a nil error wins: Hedge returns its HedgeResult
+// and cancels the shared context passed to fn, so every other in-flight
+// attempt observes context.Canceled on its next check of ctx. Like
+// Execute, Hedge gives up once the overall budget context expires,
+// returning ErrBudgetExceeded wrapping the last error seen; if every source
+// is launched and fails before that happens, Hedge returns the last
+// failure directly.
+func (a *BudgetArbiter) Hedge(sources []string, fn func(ctx context.Context, source string) error) (HedgeResult, error) {
+	if len(sources) == 0 {
+		return HedgeResult{}, errors.New("hedge requires at least one source")
+	}
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	defer cancel()
+
+	results := make(chan hedgeAttempt, len(sources))
+	launch := func(attempt int, source string) {
+		go func() {
+			start := time.Now()
+			err := fn(ctx, source)
+			a.latencyFor(source).Observe(time.Since(start))
+			a.metrics.ObserveSource(source, time.Since(start), err)
+			results <- hedgeAttempt{source: source, attempt: attempt, err: err}
+		}()
+	}
+
+	launch(1, sources[0])
+	launched := 1
+	fired := 0
+
+	var timer *time.Timer
+	var timerC <-chan time.Time
+	if launched < len(sources) {
+		timer = time.NewTimer(a.hedgeDelayFor(sources[launched]))
+		timerC = timer.C
+		defer timer.Stop()
+	}
+
+	var lastErr error
+	failures := 0
+	for {
+		select {
+		case res := <-results:
+			if res.err == nil {
+				cancel()
+				obs.IncHedgeWon(res.source, strconv.Itoa(res.attempt))
+				return HedgeResult{Source: res.source, Hedges: fired}, nil
+			}
+			failures++
+			lastErr = res.err
+			if failures == launched && launched == len(sources) {
+				return HedgeResult{Hedges: fired}, lastErr
+			}
+
+		case <-timerC:
+			source := sources[launched]
+			launched++
+			fired++
+			obs.IncHedgeFired(source)
+			launch(launched, source)
+			if launched < len(sources) {
+				timer.Reset(a.hedgeDelayFor(sources[launched]))
+			} else {
+				timerC = nil
+			}
+
+		case <-a.ctx.Done():
+			return HedgeResult{Hedges: fired}, errBudgetExceeded.withCause(lastErr)
+		}
+	}
+}
+
+// budgetSafetyFactor multiplies a source's observed p95 latency when
+// deriving its sub-deadline, absorbing normal jitter without letting a
+// historically slow source consume the full remaining budget.
+const budgetSafetyFactor = 2.0
+
+// BudgetPlan records the sub-deadline derived for each source given the
+// overall request budget and that source's recently observed p95 latency,
+// expressed as a duration from the call start so it survives inclusion in
+// traces independent of wall-clock skew.
+// mvp-5
+type BudgetPlan struct {
+	Overall   time.Duration
+	PerSource map[string]time.Duration
+}
+
+// DeriveBudgetPlan caps each source's sub-budget at whichever is smaller:
+// the overall remaining request budget, or budgetSafetyFactor times that
+// source's own recent p95 latency. A fast source (low p95) is effectively
+// left with the full overall budget as headroom, while a source that is
+// already running slow is cancelled before the overall budget elapses
+// rather than left to consume it. overall <= 0 means the request itself
+// has no deadline, so only the per-source p95 bound applies; a source
+// with no latency history yet (p95 == 0) is left unbounded by this plan.
+func DeriveBudgetPlan(overall time.Duration, p95 map[string]time.Duration) BudgetPlan {
+	plan := BudgetPlan{Overall: overall, PerSource: make(map[string]time.Duration, len(p95))}
+	for name, latency := range p95 {
+		sub := overall
+		if latency > 0 {
+			if scaled := time.Duration(float64(latency) * budgetSafetyFactor); sub <= 0 || scaled < sub {
+				sub = scaled
+			}
+		}
+		plan.PerSource[name] = sub
+	}
+	return plan
+}
+
+// DeadlineFor returns the absolute deadline derived for source starting at
+// start, and whether one applies at all. ok is false when neither the
+// overall budget nor a latency history bounds this source, in which case
+// the caller should leave the parent context's own deadline (if any)
+// untouched.
+func (p BudgetPlan) DeadlineFor(source string, start time.Time) (time.Time, bool) {
+	sub, tracked := p.PerSource[source]
+	if !tracked || sub <= 0 {
+		return time.Time{}, false
+	}
+	return start.Add(sub), true
 }
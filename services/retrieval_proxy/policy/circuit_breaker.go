@@ -20,22 +20,29 @@ const (
 type circuitEvent struct {
 	timestamp time.Time
 	success   bool
+	class     string
 }
 
 // CircuitBreakerConfig configures the circuit breaker behaviour.
 type CircuitBreakerConfig struct {
-	Window              time.Duration
+	Window               time.Duration
 	FailureRateThreshold float64
-	MinSamples          int
-	Cooldown            time.Duration
-	HalfOpenMaxCalls    int
+	MinSamples           int
+	Cooldown             time.Duration
+	HalfOpenMaxCalls     int
+
+	// ClassThresholds overrides FailureRateThreshold for specific error
+	// classes (as produced by classifyError), e.g. tolerating a higher
+	// rate of "rate_limited" than "timeout". A class absent from this map
+	// falls back to FailureRateThreshold.
+	ClassThresholds map[string]float64
 }
 
 // CircuitBreaker implements a rolling window circuit breaker with half-open support.
 type CircuitBreaker struct {
-	cfg      CircuitBreakerConfig
-	source   string
-	metrics  *Metrics
+	cfg     CircuitBreakerConfig
+	source  string
+	metrics *Metrics
 
 	mu                sync.Mutex
 	state             CircuitState
@@ -45,13 +52,36 @@ type CircuitBreaker struct {
 	halfOpenSuccesses int
 }
 
+// defaultCircuitBreakerConfig fills zero-valued fields of cfg with the
+// package's default circuit breaker behaviour: a 10s rolling window, 50%
+// failure rate threshold, a minimum of 3 samples before evaluating, a 2s
+// cooldown before probing Half-Open, and 1 concurrent Half-Open probe.
+func defaultCircuitBreakerConfig(cfg CircuitBreakerConfig) CircuitBreakerConfig {
+	if cfg.Window <= 0 {
+		cfg.Window = 10 * time.Second
+	}
+	if cfg.FailureRateThreshold <= 0 {
+		cfg.FailureRateThreshold = 0.5
+	}
+	if cfg.MinSamples <= 0 {
+		cfg.MinSamples = 3
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = 2 * time.Second
+	}
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = 1
+	}
+	return cfg
+}
+
 // NewCircuitBreaker constructs a new CircuitBreaker.
 func NewCircuitBreaker(source string, cfg CircuitBreakerConfig, metrics *Metrics) *CircuitBreaker {
 	cb := &CircuitBreaker{
-		cfg:    cfg,
-		source: source,
+		cfg:     cfg,
+		source:  source,
 		metrics: metrics,
-		state:  CircuitClosed,
+		state:   CircuitClosed,
 	}
 	cb.updateMetrics(CircuitClosed)
 	return cb
@@ -78,12 +108,15 @@ func (c *CircuitBreaker) Allow(now time.Time) bool {
 	return true
 }
 
-// Record records the outcome of a call.
-func (c *CircuitBreaker) Record(now time.Time, success bool) {
+// Record records the outcome of a call, optionally tagged with the error
+// class (as produced by classifyError) so per-class failure rates can be
+// tracked. class is ignored on success and defaults to "other" on failure
+// when omitted.
+func (c *CircuitBreaker) Record(now time.Time, success bool, class ...string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.addEvent(now, success)
+	c.addEvent(now, success, recordClass(success, class))
 	c.refreshState(now)
 
 	if c.state == CircuitHalfOpen {
@@ -100,10 +133,21 @@ func (c *CircuitBreaker) Record(now time.Time, success bool) {
 	}
 }
 
-func (c *CircuitBreaker) addEvent(now time.Time, success bool) {
+func recordClass(success bool, class []string) string {
+	if success {
+		return "ok"
+	}
+	if len(class) > 0 && class[0] != "" {
+		return class[0]
+	}
+	return "other"
+}
+
+func (c *CircuitBreaker) addEvent(now time.Time, success bool, class string) {
 	c.events = append(c.events, circuitEvent{
 		timestamp: now,
 		success:   success,
+		class:     class,
 	})
 	c.prune(now)
 }
@@ -136,24 +180,46 @@ func (c *CircuitBreaker) refreshState(now time.Time) {
 		return
 	}
 
-	// Evaluate failure rate in closed state.
+	// Evaluate failure rate in closed state, per error class.
 	c.prune(now)
 	total := len(c.events)
 	if total < c.cfg.MinSamples || total == 0 {
 		return
 	}
 
-	failures := 0
+	classCounts := make(map[string]int)
 	for _, evt := range c.events {
 		if !evt.success {
-			failures++
+			classCounts[evt.class]++
+		}
+	}
+
+	for class, count := range classCounts {
+		threshold := c.cfg.FailureRateThreshold
+		if override, ok := c.cfg.ClassThresholds[class]; ok {
+			threshold = override
+		}
+		if float64(count)/float64(total) >= threshold {
+			c.transition(CircuitOpen, now)
+			return
 		}
 	}
+}
+
+// Snapshot returns the current per-error-class failure counts within the
+// rolling window, for tests and debugging.
+func (c *CircuitBreaker) Snapshot() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	failureRate := float64(failures) / float64(total)
-	if failureRate >= c.cfg.FailureRateThreshold {
-		c.transition(CircuitOpen, now)
+	c.prune(time.Now())
+	counts := make(map[string]int)
+	for _, evt := range c.events {
+		if !evt.success {
+			counts[evt.class]++
+		}
 	}
+	return counts
 }
 
 func (c *CircuitBreaker) transition(state CircuitState, now time.Time) {
@@ -182,4 +248,3 @@ func (c *CircuitBreaker) State() CircuitState {
 	defer c.mu.Unlock()
 	return c.state
 }
-
@@ -3,13 +3,20 @@ package policy
 import (
 	"context"
 	"fmt"
+	"sync"
 )
 
 // Controller wires together the budget arbiter with per-source policies.
 type Controller struct {
+	mu      sync.RWMutex
 	budget  *BudgetArbiter
-	sources map[string]*SourcePolicy
+	cfg     ControllerConfig
 	metrics *Metrics
+
+	sources map[string]*SourcePolicy
+	// tenantSources caches tenant-scoped policy views, built lazily on first
+	// access and invalidated wholesale by Reload.
+	tenantSources map[string]map[string]*SourcePolicy
 }
 
 // ControllerConfig groups the top-level policy configuration.
@@ -29,31 +36,135 @@ func NewController(ctx context.Context, cfg ControllerConfig, metrics *Metrics)
 		return nil, fmt.Errorf("budget arbiter: %w", err)
 	}
 
-	sourcePolicies := make(map[string]*SourcePolicy, len(cfg.Sources))
-	for _, sc := range cfg.Sources {
-		policy, err := NewSourcePolicy(sc, metrics)
-		if err != nil {
-			return nil, fmt.Errorf("source %q: %w", sc.Name, err)
-		}
-		sourcePolicies[sc.Name] = policy
+	sourcePolicies, err := buildSourcePolicies(cfg.Sources, metrics)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Controller{
-		budget:  budget,
-		sources: sourcePolicies,
-		metrics: metrics,
+		budget:        budget,
+		cfg:           cfg,
+		metrics:       metrics,
+		sources:       sourcePolicies,
+		tenantSources: make(map[string]map[string]*SourcePolicy),
 	}, nil
 }
 
+func buildSourcePolicies(sources []SourceConfig, metrics *Metrics) (map[string]*SourcePolicy, error) {
+	sourcePolicies := make(map[string]*SourcePolicy, len(sources))
+	for _, sc := range sources {
+		p, err := NewSourcePolicy(sc, metrics)
+		if err != nil {
+			return nil, fmt.Errorf("source %q: %w", sc.Name, err)
+		}
+		sourcePolicies[sc.Name] = p
+	}
+	return sourcePolicies, nil
+}
+
 // Budget returns the budget arbiter.
 func (c *Controller) Budget() *BudgetArbiter {
 	return c.budget
 }
 
-// Source returns the policy for the requested source.
-func (c *Controller) Source(name string) (*SourcePolicy, bool) {
-	policy, ok := c.sources[name]
-	return policy, ok
+// Source returns the tenant-scoped policy view for the requested source.
+// tenantID may be empty, in which case the source's shared policy is
+// returned. When tenantID is non-empty but the source declares no override
+// for it, the shared policy is returned as well, so unknown tenants are
+// never left unthrottled.
+func (c *Controller) Source(name, tenantID string) (*SourcePolicy, bool) {
+	if tenantID == "" {
+		c.mu.RLock()
+		p, ok := c.sources[name]
+		c.mu.RUnlock()
+		return p, ok
+	}
+
+	c.mu.RLock()
+	if byTenant, ok := c.tenantSources[name]; ok {
+		if p, ok := byTenant[tenantID]; ok {
+			c.mu.RUnlock()
+			return p, true
+		}
+	}
+	c.mu.RUnlock()
+
+	return c.tenantSource(name, tenantID)
+}
+
+// tenantSource builds and caches a tenant-scoped SourcePolicy the first time
+// a given (source, tenant) pair is requested, so every call sees the same
+// token bucket and circuit breaker instance instead of a fresh one per call.
+func (c *Controller) tenantSource(name, tenantID string) (*SourcePolicy, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if byTenant, ok := c.tenantSources[name]; ok {
+		if p, ok := byTenant[tenantID]; ok {
+			return p, true
+		}
+	}
+
+	base, ok := c.sources[name]
+	if !ok {
+		return nil, false
+	}
+
+	sc, ok := c.sourceConfig(name)
+	if !ok {
+		return base, true
+	}
+	override, ok := sc.TenantOverrides[tenantID]
+	if !ok {
+		return base, true
+	}
+
+	tenantCfg := sc
+	if override.Rate != (RateLimitConfig{}) {
+		tenantCfg.Rate = override.Rate
+	}
+	if override.Timeout > 0 {
+		tenantCfg.Timeout = override.Timeout
+	}
+
+	p, err := NewSourcePolicy(tenantCfg, c.metrics)
+	if err != nil {
+		return base, true
+	}
+	p.tenant = tenantID
+
+	if c.tenantSources[name] == nil {
+		c.tenantSources[name] = make(map[string]*SourcePolicy)
+	}
+	c.tenantSources[name][tenantID] = p
+	return p, true
+}
+
+func (c *Controller) sourceConfig(name string) (SourceConfig, bool) {
+	for _, sc := range c.cfg.Sources {
+		if sc.Name == name {
+			return sc, true
+		}
+	}
+	return SourceConfig{}, false
+}
+
+// Reload atomically swaps in a new ControllerConfig, rebuilding every
+// source policy (and discarding cached tenant-scoped views) so tenant
+// overrides, rate limits, and circuit breaker settings can change without
+// restarting the process. The budget arbiter is left untouched.
+func (c *Controller) Reload(cfg ControllerConfig) error {
+	sourcePolicies, err := buildSourcePolicies(cfg.Sources, c.metrics)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.cfg = cfg
+	c.sources = sourcePolicies
+	c.tenantSources = make(map[string]map[string]*SourcePolicy)
+	c.mu.Unlock()
+	return nil
 }
 
 // Metrics returns the metrics collector.
@@ -0,0 +1,320 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/searchforge/retrieval_proxy/obs"
+)
+
+// Peer identifies a replica that can own distributed rate-limit keys.
+type Peer struct {
+	ID   string
+	Addr string
+}
+
+// PeerDiscovery resolves the current set of peers eligible to own rate-limit
+// keys. Implementations may be backed by a static list, DNS SRV lookups, or
+// any other membership source.
+type PeerDiscovery interface {
+	Peers(ctx context.Context) ([]Peer, error)
+}
+
+// StaticPeers is a PeerDiscovery backed by a fixed list configured at startup.
+type StaticPeers []Peer
+
+// Peers returns the fixed peer list.
+func (s StaticPeers) Peers(context.Context) ([]Peer, error) {
+	return []Peer(s), nil
+}
+
+// PeerDiscoveryFunc adapts a plain function to PeerDiscovery.
+type PeerDiscoveryFunc func(ctx context.Context) ([]Peer, error)
+
+// Peers invokes the underlying function.
+func (f PeerDiscoveryFunc) Peers(ctx context.Context) ([]Peer, error) {
+	return f(ctx)
+}
+
+// RateLimitTransport forwards Allow/GetPeerRateLimit decisions to the owning
+// peer, typically over gRPC or HTTP.
+type RateLimitTransport interface {
+	Allow(ctx context.Context, peer Peer, key string) (bool, error)
+	GetPeerRateLimit(ctx context.Context, peer Peer, key string) (PeerRateLimitStatus, error)
+}
+
+// PeerRateLimitStatus reports the owning peer's view of a rate-limit key.
+type PeerRateLimitStatus struct {
+	Remaining int
+	ResetAt   time.Time
+}
+
+// RateLimitAlgorithm selects the admission algorithm used for a source.
+type RateLimitAlgorithm string
+
+const (
+	// AlgorithmTokenBucket is the classic capacity/refill limiter.
+	AlgorithmTokenBucket RateLimitAlgorithm = "token_bucket"
+	// AlgorithmLeakyBucket admits requests based on a trailing-window hit count.
+	AlgorithmLeakyBucket RateLimitAlgorithm = "leaky_bucket"
+)
+
+// LeakyBucketConfig configures a trailing-window leaky bucket limiter.
+type LeakyBucketConfig struct {
+	Limit  int
+	Window time.Duration
+}
+
+// leakyBucket admits requests if fewer than Limit hits fall within the
+// trailing Window, using a bounded ring buffer with amortized O(1) eviction.
+type leakyBucket struct {
+	mu    sync.Mutex
+	cfg   LeakyBucketConfig
+	hits  []time.Time
+	head  int
+	count int
+}
+
+func newLeakyBucket(cfg LeakyBucketConfig) *leakyBucket {
+	if cfg.Limit <= 0 || cfg.Window <= 0 {
+		return nil
+	}
+	return &leakyBucket{
+		cfg:  cfg,
+		hits: make([]time.Time, cfg.Limit),
+	}
+}
+
+// Allow evicts expired entries and admits the hit if the window is not full.
+func (l *leakyBucket) Allow(now time.Time) bool {
+	if l == nil {
+		return true
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := now.Add(-l.cfg.Window)
+	for l.count > 0 {
+		oldest := l.hits[(l.head-l.count+len(l.hits))%len(l.hits)]
+		if oldest.After(cutoff) {
+			break
+		}
+		l.count--
+	}
+
+	if l.count >= l.cfg.Limit {
+		return false
+	}
+
+	l.hits[l.head] = now
+	l.head = (l.head + 1) % len(l.hits)
+	l.count++
+	return true
+}
+
+// hashRing is a consistent-hash ring used to elect the owner replica for a
+// rate-limit key.
+type hashRing struct {
+	points []uint32
+	owners map[uint32]Peer
+}
+
+const ringReplicas = 64
+
+func newHashRing(peers []Peer) *hashRing {
+	r := &hashRing{owners: make(map[uint32]Peer, len(peers)*ringReplicas)}
+	for _, p := range peers {
+		for i := 0; i < ringReplicas; i++ {
+			h := ringHash(p.ID, i)
+			r.points = append(r.points, h)
+			r.owners[h] = p
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i] < r.points[j] })
+	return r
+}
+
+func ringHash(id string, replica int) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+	_, _ = h.Write([]byte{byte(replica), byte(replica >> 8)})
+	return h.Sum32()
+}
+
+// Owner returns the peer responsible for the given rate-limit key.
+func (r *hashRing) Owner(key string) (Peer, bool) {
+	if r == nil || len(r.points) == 0 {
+		return Peer{}, false
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	target := h.Sum32()
+
+	idx := sort.Search(len(r.points), func(i int) bool { return r.points[i] >= target })
+	if idx == len(r.points) {
+		idx = 0
+	}
+	return r.owners[r.points[idx]], true
+}
+
+// ErrRateLimitDegraded indicates the owning peer could not be reached and the
+// limiter fell back to strict-local mode.
+var ErrRateLimitDegraded = errors.New("rate limiter degraded to local mode")
+
+// DistributedRateLimiterConfig configures a DistributedRateLimiter.
+type DistributedRateLimiterConfig struct {
+	Algorithm   RateLimitAlgorithm
+	TokenBucket RateLimitConfig
+	LeakyBucket LeakyBucketConfig
+	Discovery   PeerDiscovery
+	Transport   RateLimitTransport
+	Self        Peer
+	CacheTTL    time.Duration
+}
+
+type cachedDecision struct {
+	allowed  bool
+	expireAt time.Time
+}
+
+// DistributedRateLimiter hashes a rate-limit key to an owning peer and
+// forwards the Allow decision to it, with local read-through caching and a
+// strict-local fallback when the owner is unreachable.
+type DistributedRateLimiter struct {
+	cfg     DistributedRateLimiterConfig
+	source  string
+	local   *TokenBucket
+	leaky   *leakyBucket
+	metrics *Metrics
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedDecision
+}
+
+// NewDistributedRateLimiter constructs a DistributedRateLimiter for source.
+func NewDistributedRateLimiter(source string, cfg DistributedRateLimiterConfig, metrics *Metrics) (*DistributedRateLimiter, error) {
+	if cfg.Discovery == nil {
+		cfg.Discovery = StaticPeers(nil)
+	}
+
+	d := &DistributedRateLimiter{
+		cfg:     cfg,
+		source:  source,
+		metrics: metrics,
+		cache:   make(map[string]cachedDecision),
+	}
+
+	switch cfg.Algorithm {
+	case AlgorithmLeakyBucket:
+		d.leaky = newLeakyBucket(cfg.LeakyBucket)
+		if d.leaky == nil {
+			return nil, errors.New("leaky bucket requires positive limit and window")
+		}
+	default:
+		d.cfg.Algorithm = AlgorithmTokenBucket
+		d.local = NewTokenBucket(cfg.TokenBucket.Capacity, cfg.TokenBucket.RefillTokens, cfg.TokenBucket.RefillEvery)
+		if d.local == nil {
+			return nil, errors.New("token bucket requires positive capacity, refill tokens, and refill interval")
+		}
+	}
+
+	return d, nil
+}
+
+// Allow reports whether the request identified by key may proceed, either by
+// consulting the local bucket (when this replica owns the key) or by
+// forwarding the decision to the owning peer.
+func (d *DistributedRateLimiter) Allow(ctx context.Context, key string) (bool, error) {
+	if d == nil {
+		return true, nil
+	}
+
+	peers, err := d.cfg.Discovery.Peers(ctx)
+	if err != nil || len(peers) == 0 {
+		return d.allowLocal(time.Now()), nil
+	}
+
+	ring := newHashRing(peers)
+	owner, ok := ring.Owner(key)
+	if !ok || owner.ID == d.cfg.Self.ID || d.cfg.Transport == nil {
+		return d.allowLocal(time.Now()), nil
+	}
+
+	if allowed, ok := d.readCache(key); ok {
+		return allowed, nil
+	}
+
+	allowed, err := d.cfg.Transport.Allow(ctx, owner, key)
+	if err != nil {
+		obs.IncPolicyRateLimitDegraded(d.source)
+		return d.allowLocal(time.Now()), ErrRateLimitDegraded
+	}
+
+	d.writeCache(key, allowed)
+	return allowed, nil
+}
+
+// GetPeerRateLimit reports the owning peer's current view of key. It
+// returns a zero PeerRateLimitStatus with a nil error when there is no
+// transport, no peer, or this replica owns key, and a zero
+// PeerRateLimitStatus with ErrRateLimitDegraded when the owner is
+// unreachable; no local snapshot is reported in either case.
+func (d *DistributedRateLimiter) GetPeerRateLimit(ctx context.Context, key string) (PeerRateLimitStatus, error) {
+	if d == nil || d.cfg.Transport == nil {
+		return PeerRateLimitStatus{}, nil
+	}
+
+	peers, err := d.cfg.Discovery.Peers(ctx)
+	if err != nil || len(peers) == 0 {
+		return PeerRateLimitStatus{}, nil
+	}
+
+	ring := newHashRing(peers)
+	owner, ok := ring.Owner(key)
+	if !ok || owner.ID == d.cfg.Self.ID {
+		return PeerRateLimitStatus{}, nil
+	}
+
+	status, err := d.cfg.Transport.GetPeerRateLimit(ctx, owner, key)
+	if err != nil {
+		obs.IncPolicyRateLimitDegraded(d.source)
+		return PeerRateLimitStatus{}, ErrRateLimitDegraded
+	}
+	return status, nil
+}
+
+func (d *DistributedRateLimiter) allowLocal(now time.Time) bool {
+	switch d.cfg.Algorithm {
+	case AlgorithmLeakyBucket:
+		return d.leaky.Allow(now)
+	default:
+		return d.local.Allow(now)
+	}
+}
+
+func (d *DistributedRateLimiter) readCache(key string) (bool, bool) {
+	if d.cfg.CacheTTL <= 0 {
+		return false, false
+	}
+	d.cacheMu.Lock()
+	defer d.cacheMu.Unlock()
+
+	entry, ok := d.cache[key]
+	if !ok || time.Now().After(entry.expireAt) {
+		return false, false
+	}
+	return entry.allowed, true
+}
+
+func (d *DistributedRateLimiter) writeCache(key string, allowed bool) {
+	if d.cfg.CacheTTL <= 0 {
+		return
+	}
+	d.cacheMu.Lock()
+	defer d.cacheMu.Unlock()
+	d.cache[key] = cachedDecision{allowed: allowed, expireAt: time.Now().Add(d.cfg.CacheTTL)}
+}
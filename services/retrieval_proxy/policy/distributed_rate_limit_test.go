@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHashRingOwnerIsStablePerKey(t *testing.T) {
+	peers := []Peer{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	ring := newHashRing(peers)
+
+	first, ok := ring.Owner("source|collection")
+	if !ok {
+		t.Fatal("expected a non-empty ring to resolve an owner")
+	}
+	for i := 0; i < 10; i++ {
+		owner, ok := ring.Owner("source|collection")
+		if !ok || owner.ID != first.ID {
+			t.Fatalf("expected the same key to consistently hash to the same owner, got %v then %v", first, owner)
+		}
+	}
+}
+
+type erroringTransport struct{}
+
+func (erroringTransport) Allow(ctx context.Context, peer Peer, key string) (bool, error) {
+	return false, errors.New("peer unreachable")
+}
+
+func (erroringTransport) GetPeerRateLimit(ctx context.Context, peer Peer, key string) (PeerRateLimitStatus, error) {
+	return PeerRateLimitStatus{}, errors.New("peer unreachable")
+}
+
+func TestDistributedRateLimiterFallsBackToLocalOnTransportError(t *testing.T) {
+	d, err := NewDistributedRateLimiter("fake", DistributedRateLimiterConfig{
+		TokenBucket: RateLimitConfig{Capacity: 1, RefillTokens: 1, RefillEvery: time.Hour},
+		Discovery:   StaticPeers{{ID: "remote"}},
+		Transport:   erroringTransport{},
+		Self:        Peer{ID: "local"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	allowed, err := d.Allow(context.Background(), "some-key")
+	if !errors.Is(err, ErrRateLimitDegraded) {
+		t.Fatalf("expected ErrRateLimitDegraded when the owning peer is unreachable, got %v", err)
+	}
+	if !allowed {
+		t.Fatal("expected the first call to be allowed by the local fallback bucket")
+	}
+
+	allowed, err = d.Allow(context.Background(), "some-key")
+	if !errors.Is(err, ErrRateLimitDegraded) {
+		t.Fatalf("expected ErrRateLimitDegraded on the second call too, got %v", err)
+	}
+	if allowed {
+		t.Fatal("expected the local fallback bucket to be exhausted after its one-token capacity")
+	}
+}
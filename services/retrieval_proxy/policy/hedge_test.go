@@ -0,0 +1,84 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBudgetArbiterHedgeWinnerContextPreserved(t *testing.T) {
+	arbiter, err := NewBudgetArbiter(context.Background(), 200, nil, WithHedgeDelay(5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer arbiter.Cancel()
+
+	res, err := arbiter.Hedge([]string{"primary", "secondary"}, func(ctx context.Context, source string) error {
+		if source == "primary" {
+			if ctx.Err() != nil {
+				t.Errorf("primary context canceled before it could return")
+			}
+			return nil
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Source != "primary" {
+		t.Fatalf("expected primary to win, got %q", res.Source)
+	}
+}
+
+func TestBudgetArbiterHedgeLoserObservesCanceled(t *testing.T) {
+	arbiter, err := NewBudgetArbiter(context.Background(), 200, nil, WithHedgeDelay(5*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer arbiter.Cancel()
+
+	loserErr := make(chan error, 1)
+	_, err = arbiter.Hedge([]string{"slow", "fast"}, func(ctx context.Context, source string) error {
+		if source == "fast" {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}
+		<-ctx.Done()
+		loserErr <- ctx.Err()
+		return ctx.Err()
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case got := <-loserErr:
+		if !errors.Is(got, context.Canceled) {
+			t.Fatalf("expected loser context to observe context.Canceled, got %v", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for losing attempt to observe cancellation")
+	}
+}
+
+func TestBudgetArbiterHedgeRespectsBudget(t *testing.T) {
+	start := time.Now()
+	arbiter, err := NewBudgetArbiter(context.Background(), 50, nil, WithHedgeDelay(10*time.Millisecond))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer arbiter.Cancel()
+
+	_, err = arbiter.Hedge([]string{"a", "b", "c"}, func(ctx context.Context, source string) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("expected ErrBudgetExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected Hedge to return close to the 50ms budget, took %v", elapsed)
+	}
+}
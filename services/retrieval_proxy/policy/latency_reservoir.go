@@ -0,0 +1,85 @@
+package policy
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+)
+
+const defaultReservoirSize = 1024
+
+// LatencyReservoir maintains a fixed-size window of the most recent
+// latencies so callers can estimate a quantile (e.g. p95) without the
+// bucketing loss of a Prometheus histogram.
+type LatencyReservoir struct {
+	mu      sync.Mutex
+	size    int
+	count   int64
+	samples []time.Duration
+	rnd     *rand.Rand
+}
+
+// NewLatencyReservoir constructs a reservoir holding up to size samples.
+func NewLatencyReservoir(size int) *LatencyReservoir {
+	if size <= 0 {
+		size = defaultReservoirSize
+	}
+	return &LatencyReservoir{
+		size:    size,
+		samples: make([]time.Duration, 0, size),
+		rnd:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Observe records a latency sample. Once size samples are held, each new
+// sample overwrites the oldest, so the reservoir always holds the most
+// recent size observations.
+func (r *LatencyReservoir) Observe(d time.Duration) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.count++
+	if len(r.samples) < r.size {
+		r.samples = append(r.samples, d)
+		return
+	}
+	r.samples[(r.count-1)%int64(r.size)] = d
+}
+
+// Percentile returns the p-th quantile (0 < p <= 1) of the current sample,
+// or zero if no samples have been observed yet.
+func (r *LatencyReservoir) Percentile(p float64) time.Duration {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	n := len(r.samples)
+	if n == 0 {
+		r.mu.Unlock()
+		return 0
+	}
+	sorted := make([]time.Duration, n)
+	copy(sorted, r.samples)
+	r.mu.Unlock()
+
+	if p <= 0 {
+		p = 0.95
+	}
+	if p > 1 {
+		p = 1
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(p * float64(n-1))
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= n {
+		idx = n - 1
+	}
+	return sorted[idx]
+}
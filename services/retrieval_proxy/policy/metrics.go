@@ -1,22 +1,44 @@
 package policy
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/searchforge/retrieval_proxy/obs"
 )
 
-// Metrics wraps policy specific Prometheus metrics.
+// Metrics wraps policy specific Prometheus metrics, plus an optional
+// OpenTelemetry backend enabled via WithOTelMeterProvider.
 type Metrics struct {
 	perSourceLatency *prometheus.HistogramVec
 	perSourceErrRate *prometheus.GaugeVec
-	totalLatency     *prometheus.Histogram
-	circuitState     *prometheus.GaugeVec
-	budgetHit        prometheus.Counter
+	totalLatency     prometheus.Histogram
 
 	requestsMu sync.Mutex
 	requests   map[string]*sourceRequestStats
+
+	budgetHitCount int64
+
+	otel *otelInstruments
+
+	stateMu       sync.Mutex
+	circuitStates map[string]float64
+}
+
+// otelInstruments mirrors the Prometheus collectors above as OpenTelemetry
+// metric instruments. It is nil unless WithOTelMeterProvider is given.
+type otelInstruments struct {
+	sourceLatency otelmetric.Float64Histogram
+	totalLatency  otelmetric.Float64Histogram
+	budgetHit     otelmetric.Int64Counter
+	errRate       otelmetric.Float64ObservableGauge
+	circuitState  otelmetric.Float64ObservableGauge
 }
 
 type sourceRequestStats struct {
@@ -28,8 +50,9 @@ type sourceRequestStats struct {
 type MetricsOption func(*metricsConfig)
 
 type metricsConfig struct {
-	registerer prometheus.Registerer
-	buckets    []float64
+	registerer    prometheus.Registerer
+	buckets       []float64
+	meterProvider otelmetric.MeterProvider
 }
 
 // WithRegisterer overrides the default Prometheus registerer.
@@ -46,6 +69,15 @@ func WithLatencyBuckets(buckets []float64) MetricsOption {
 	}
 }
 
+// WithOTelMeterProvider additionally records every metric through an
+// OpenTelemetry meter obtained from mp, alongside the Prometheus
+// collectors. Pass nil (the default) to leave the OTel backend disabled.
+func WithOTelMeterProvider(mp otelmetric.MeterProvider) MetricsOption {
+	return func(cfg *metricsConfig) {
+		cfg.meterProvider = mp
+	}
+}
+
 // NewMetrics constructs Metrics and registers Prometheus collectors.
 func NewMetrics(opts ...MetricsOption) *Metrics {
 	cfg := metricsConfig{
@@ -76,30 +108,21 @@ func NewMetrics(opts ...MetricsOption) *Metrics {
 		Buckets: cfg.buckets,
 	})
 
-	circuitState := prometheus.NewGaugeVec(prometheus.GaugeOpts{
-		Name: "retrieval_proxy_circuit_state",
-		Help: "Circuit breaker state for each upstream retrieval source. 0=closed, 1=half-open, 2=open.",
-	}, []string{"source"})
-
-	budgetHit := prometheus.NewCounter(prometheus.CounterOpts{
-		Name: "retrieval_proxy_budget_hit_total",
-		Help: "Total number of requests that hit the configured budget.",
-	})
-
 	m := &Metrics{
 		perSourceLatency: perSourceLatency,
 		perSourceErrRate: perSourceErrRate,
 		totalLatency:     totalLatency,
-		circuitState:     circuitState,
-		budgetHit:        budgetHit,
 		requests:         make(map[string]*sourceRequestStats),
+		circuitStates:    make(map[string]float64),
 	}
 
 	perSourceLatency = registerHistogramVec(cfg.registerer, perSourceLatency)
 	perSourceErrRate = registerGaugeVec(cfg.registerer, perSourceErrRate)
 	totalLatency = registerHistogram(cfg.registerer, totalLatency)
-	circuitState = registerGaugeVec(cfg.registerer, circuitState)
-	budgetHit = registerCounter(cfg.registerer, budgetHit)
+
+	if cfg.meterProvider != nil {
+		m.otel = newOTelInstruments(cfg.meterProvider, m)
+	}
 
 	return m
 }
@@ -135,6 +158,10 @@ func (m *Metrics) ObserveSource(source string, latency time.Duration, err error)
 	m.requestsMu.Unlock()
 
 	m.perSourceErrRate.WithLabelValues(source).Set(rate)
+
+	if m.otel != nil {
+		m.otel.sourceLatency.Record(context.Background(), ms, otelmetric.WithAttributes(sourceAttr(source)))
+	}
 }
 
 // ObserveTotal records the total latency for the proxy request.
@@ -147,47 +174,178 @@ func (m *Metrics) ObserveTotal(latency time.Duration) {
 		ms = 0
 	}
 	m.totalLatency.Observe(ms)
+
+	if m.otel != nil {
+		m.otel.totalLatency.Record(context.Background(), ms)
+	}
 }
 
-// IncBudgetHit increments the budget hit counter.
+// IncBudgetHit increments the budget hit counter, via obs.IncBudgetHit so
+// policy and the rest of the proxy share a single retrieval_proxy_budget_hit_total
+// counter instead of registering competing collectors under the same name.
 func (m *Metrics) IncBudgetHit() {
 	if m == nil {
 		return
 	}
-	m.budgetHit.Inc()
+	obs.IncBudgetHit()
+	atomic.AddInt64(&m.budgetHitCount, 1)
+
+	if m.otel != nil {
+		m.otel.budgetHit.Add(context.Background(), 1)
+	}
 }
 
-// SetCircuitState records the circuit breaker state for a source.
+// SetCircuitState records the circuit breaker state for a source, via
+// obs.SetCircuitState so policy and the rest of the proxy share a single
+// retrieval_proxy_circuit_state gauge instead of registering competing
+// collectors under the same name.
 func (m *Metrics) SetCircuitState(source string, state CircuitState) {
 	if m == nil {
 		return
 	}
-	m.circuitState.WithLabelValues(source).Set(float64(state))
+	obs.SetCircuitState(source, circuitStateLabel(state))
+
+	m.stateMu.Lock()
+	m.circuitStates[source] = float64(state)
+	m.stateMu.Unlock()
 }
 
-func registerHistogramVec(registerer prometheus.Registerer, collector *prometheus.HistogramVec) *prometheus.HistogramVec {
-	if registerer == nil {
-		return collector
+// circuitStateLabel maps a CircuitState to the label obs.SetCircuitState
+// expects.
+func circuitStateLabel(state CircuitState) string {
+	switch state {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
 	}
-	if err := registerer.Register(collector); err != nil {
-		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
-				return existing
-			}
-			return collector
-		}
-		panic(err)
+}
+
+// Reader exposes a point-in-time snapshot of policy metrics, letting
+// callers (typically tests) read current values directly instead of
+// scraping the Prometheus registry over HTTP.
+type Reader interface {
+	// BudgetHits returns the total number of requests that have hit the
+	// configured budget.
+	BudgetHits() int64
+	// CircuitState returns the last recorded circuit breaker state for
+	// source, and false if none has been recorded yet.
+	CircuitState(source string) (CircuitState, bool)
+	// SourceErrorRate returns the rolling error rate for source, and
+	// false if no observations have been recorded yet.
+	SourceErrorRate(source string) (float64, bool)
+}
+
+// Reader returns a Reader snapshotting m's current values.
+func (m *Metrics) Reader() Reader {
+	return m
+}
+
+// BudgetHits implements Reader.
+func (m *Metrics) BudgetHits() int64 {
+	if m == nil {
+		return 0
 	}
-	return collector
+	return atomic.LoadInt64(&m.budgetHitCount)
 }
 
-func registerGaugeVec(registerer prometheus.Registerer, collector *prometheus.GaugeVec) *prometheus.GaugeVec {
+// CircuitState implements Reader.
+func (m *Metrics) CircuitState(source string) (CircuitState, bool) {
+	if m == nil {
+		return CircuitClosed, false
+	}
+	m.stateMu.Lock()
+	defer m.stateMu.Unlock()
+	state, ok := m.circuitStates[source]
+	return CircuitState(state), ok
+}
+
+// SourceErrorRate implements Reader.
+func (m *Metrics) SourceErrorRate(source string) (float64, bool) {
+	if m == nil {
+		return 0, false
+	}
+	m.requestsMu.Lock()
+	defer m.requestsMu.Unlock()
+	stats, ok := m.requests[source]
+	if !ok {
+		return 0, false
+	}
+	total := stats.success + stats.fail
+	if total == 0 {
+		return 0, true
+	}
+	return float64(stats.fail) / float64(total), true
+}
+
+func newOTelInstruments(mp otelmetric.MeterProvider, m *Metrics) *otelInstruments {
+	meter := mp.Meter("retrieval_proxy/policy")
+
+	sourceLatency, _ := meter.Float64Histogram(
+		"retrieval_proxy_source_latency_ms",
+		otelmetric.WithDescription("Latency in milliseconds for each upstream retrieval source."),
+	)
+	totalLatency, _ := meter.Float64Histogram(
+		"retrieval_proxy_total_latency_ms",
+		otelmetric.WithDescription("Total latency in milliseconds for the retrieval proxy request."),
+	)
+	budgetHit, _ := meter.Int64Counter(
+		"retrieval_proxy_budget_hit_total",
+		otelmetric.WithDescription("Total number of requests that hit the configured budget."),
+	)
+
+	inst := &otelInstruments{
+		sourceLatency: sourceLatency,
+		totalLatency:  totalLatency,
+		budgetHit:     budgetHit,
+	}
+
+	inst.errRate, _ = meter.Float64ObservableGauge(
+		"retrieval_proxy_source_error_rate",
+		otelmetric.WithDescription("Rolling error rate for each upstream retrieval source."),
+		otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+			m.requestsMu.Lock()
+			defer m.requestsMu.Unlock()
+			for source, stats := range m.requests {
+				total := stats.success + stats.fail
+				if total == 0 {
+					continue
+				}
+				o.Observe(float64(stats.fail)/float64(total), otelmetric.WithAttributes(sourceAttr(source)))
+			}
+			return nil
+		}),
+	)
+
+	inst.circuitState, _ = meter.Float64ObservableGauge(
+		"retrieval_proxy_circuit_state",
+		otelmetric.WithDescription("Circuit breaker state for each upstream retrieval source. 0=closed, 1=half-open, 2=open."),
+		otelmetric.WithFloat64Callback(func(_ context.Context, o otelmetric.Float64Observer) error {
+			m.stateMu.Lock()
+			defer m.stateMu.Unlock()
+			for source, state := range m.circuitStates {
+				o.Observe(state, otelmetric.WithAttributes(sourceAttr(source)))
+			}
+			return nil
+		}),
+	)
+
+	return inst
+}
+
+func sourceAttr(source string) attribute.KeyValue {
+	return attribute.String("source", source)
+}
+
+func registerHistogramVec(registerer prometheus.Registerer, collector *prometheus.HistogramVec) *prometheus.HistogramVec {
 	if registerer == nil {
 		return collector
 	}
 	if err := registerer.Register(collector); err != nil {
 		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			if existing, ok := are.ExistingCollector.(*prometheus.GaugeVec); ok {
+			if existing, ok := are.ExistingCollector.(*prometheus.HistogramVec); ok {
 				return existing
 			}
 			return collector
@@ -197,13 +355,13 @@ func registerGaugeVec(registerer prometheus.Registerer, collector *prometheus.Ga
 	return collector
 }
 
-func registerHistogram(registerer prometheus.Registerer, collector *prometheus.Histogram) *prometheus.Histogram {
+func registerGaugeVec(registerer prometheus.Registerer, collector *prometheus.GaugeVec) *prometheus.GaugeVec {
 	if registerer == nil {
 		return collector
 	}
 	if err := registerer.Register(collector); err != nil {
 		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			if existing, ok := are.ExistingCollector.(*prometheus.Histogram); ok {
+			if existing, ok := are.ExistingCollector.(*prometheus.GaugeVec); ok {
 				return existing
 			}
 			return collector
@@ -213,13 +371,13 @@ func registerHistogram(registerer prometheus.Registerer, collector *prometheus.H
 	return collector
 }
 
-func registerCounter(registerer prometheus.Registerer, collector prometheus.Counter) prometheus.Counter {
+func registerHistogram(registerer prometheus.Registerer, collector prometheus.Histogram) prometheus.Histogram {
 	if registerer == nil {
 		return collector
 	}
 	if err := registerer.Register(collector); err != nil {
 		if are, ok := err.(prometheus.AlreadyRegisteredError); ok {
-			if existing, ok := are.ExistingCollector.(prometheus.Counter); ok {
+			if existing, ok := are.ExistingCollector.(prometheus.Histogram); ok {
 				return existing
 			}
 			return collector
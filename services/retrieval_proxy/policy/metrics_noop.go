@@ -24,6 +24,10 @@ func WithLatencyBuckets(_ []float64) MetricsOption {
 	return func(*metricsConfig) {}
 }
 
+func WithOTelMeterProvider(_ any) MetricsOption {
+	return func(*metricsConfig) {}
+}
+
 func (m *Metrics) ObserveSource(string, time.Duration, error) {}
 
 func (m *Metrics) ObserveTotal(time.Duration) {}
@@ -32,3 +36,17 @@ func (m *Metrics) IncBudgetHit() {}
 
 func (m *Metrics) SetCircuitState(string, CircuitState) {}
 
+type Reader interface {
+	BudgetHits() int64
+	CircuitState(source string) (CircuitState, bool)
+	SourceErrorRate(source string) (float64, bool)
+}
+
+func (m *Metrics) Reader() Reader { return m }
+
+func (m *Metrics) BudgetHits() int64 { return 0 }
+
+func (m *Metrics) CircuitState(string) (CircuitState, bool) { return CircuitClosed, false }
+
+func (m *Metrics) SourceErrorRate(string) (float64, bool) { return 0, false }
+
@@ -2,14 +2,67 @@ package policy
 
 import "errors"
 
+// netError is a minimal net.Error (Timeout() bool, Temporary() bool)
+// implementation, following the same pattern as context.DeadlineExceeded,
+// so middleware built around net/http or gRPC timeout conventions can
+// branch on budget-related errors via errors.As(err, &netErr) without
+// importing this package's sentinel types. Is lets errors.Is still match
+// the package-level sentinel by message even after withCause wraps it
+// with a call-specific cause.
+type netError struct {
+	msg       string
+	timeout   bool
+	temporary bool
+	cause     error
+}
+
+func (e *netError) Error() string {
+	if e.cause != nil {
+		return e.msg + ": " + e.cause.Error()
+	}
+	return e.msg
+}
+
+func (e *netError) Timeout() bool   { return e.timeout }
+func (e *netError) Temporary() bool { return e.temporary }
+func (e *netError) Unwrap() error   { return e.cause }
+
+func (e *netError) Is(target error) bool {
+	te, ok := target.(*netError)
+	return ok && e.msg == te.msg
+}
+
+// withCause returns a copy of e wrapping cause, so the result still
+// satisfies errors.Is against the unwrapped sentinel and errors.As against
+// net.Error, while errors.Unwrap reaches whatever error actually triggered
+// it (e.g. the context error from the failed attempt).
+func (e *netError) withCause(cause error) *netError {
+	return &netError{msg: e.msg, timeout: e.timeout, temporary: e.temporary, cause: cause}
+}
+
 var (
 	// ErrCircuitOpen indicates the circuit breaker is currently open.
 	ErrCircuitOpen = errors.New("circuit breaker open")
 	// ErrRateLimited indicates the source requests are rate limited.
 	ErrRateLimited = errors.New("rate limited")
-	// ErrBudgetExceeded indicates the overall budget has been exhausted.
-	ErrBudgetExceeded = errors.New("budget exceeded")
-	// ErrInvalidBudget indicates the provided budget is invalid.
-	ErrInvalidBudget = errors.New("invalid budget")
+)
+
+// errBudgetExceeded and errInvalidBudget back the exported sentinels below;
+// kept unexported and typed as *netError so budget.go can call withCause
+// without a type assertion.
+var (
+	errBudgetExceeded = &netError{msg: "budget exceeded", timeout: true, temporary: true}
+	errInvalidBudget  = &netError{msg: "invalid budget"}
+)
+
+var (
+	// ErrBudgetExceeded indicates the overall request budget was exhausted
+	// before a call completed. It implements net.Error like
+	// context.DeadlineExceeded (Timeout and Temporary both true).
+	ErrBudgetExceeded error = errBudgetExceeded
+	// ErrInvalidBudget indicates the provided budget is invalid. It also
+	// implements net.Error, though as a permanent input error: Timeout and
+	// Temporary are both false.
+	ErrInvalidBudget error = errInvalidBudget
 )
 
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// WatchReloadSignal reloads c's tenant configuration from path every time
+// the process receives SIGHUP, logging and discarding the error on a bad
+// reload so a malformed edit never takes down a running process. The
+// spawned goroutine exits once ctx is canceled.
+func (c *Controller) WatchReloadSignal(ctx context.Context, path string) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(sighup)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sighup:
+				if err := c.reloadFromFile(path); err != nil {
+					log.Printf("policy: tenant config reload failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// ReloadHandler returns an http.HandlerFunc suitable for mounting at
+// /admin/reload: a POST re-reads the tenant config YAML at path and
+// atomically swaps it into the controller, responding 200 on success or
+// 500 with the error otherwise.
+func (c *Controller) ReloadHandler(path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		if err := c.reloadFromFile(path); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// LoadInitial reads the tenant config YAML at path and applies it as c's
+// starting tenant overrides, using the same merge semantics ReloadHandler
+// and WatchReloadSignal apply to later reloads. Callers typically invoke
+// this once at startup when a tenant config path is configured.
+func (c *Controller) LoadInitial(path string) error {
+	return c.reloadFromFile(path)
+}
+
+func (c *Controller) reloadFromFile(path string) error {
+	c.mu.RLock()
+	base := c.cfg
+	c.mu.RUnlock()
+
+	cfg, err := LoadTenantOverrides(path, base)
+	if err != nil {
+		return err
+	}
+	return c.Reload(cfg)
+}
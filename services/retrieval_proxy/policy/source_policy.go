@@ -6,12 +6,21 @@ import (
 	"context"
 	"errors"
 	"strings"
-	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	grpccodes "google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
+
 	"github.com/searchforge/retrieval_proxy/obs"
 )
 
+// policyTracerName identifies this package's spans in exported trace data.
+const policyTracerName = "github.com/searchforge/retrieval_proxy/policy"
+
 // RateLimitConfig configures the token bucket limiter.
 // mvp-5
 type RateLimitConfig struct {
@@ -20,35 +29,111 @@ type RateLimitConfig struct {
 	RefillEvery  time.Duration
 }
 
-// CircuitConfig provides minimal circuit-breaker tuning knobs.
-// mvp-5
-type CircuitConfig struct {
-	FailureThreshold   int
-	HalfOpenSuccesses  int
-	Cooldown           time.Duration
-}
-
 // SourceConfig configures timeout, rate limit, and circuit breaker behaviour.
 // mvp-5
 type SourceConfig struct {
 	Name    string
 	Timeout time.Duration
 	Rate    RateLimitConfig
-	Circuit CircuitConfig
+	Circuit CircuitBreakerConfig
+
+	// Distributed, when set, enables fleet-wide rate limiting for this source
+	// in addition to the process-local TokenBucket above, which still applies.
+	Distributed *DistributedRateLimiterConfig
+
+	// Hedge configures speculative retries for tail-latency mitigation.
+	Hedge HedgeConfig
+
+	// AdaptiveTimeout, when enabled, replaces the static Timeout above with
+	// a deadline derived from recently observed latency.
+	AdaptiveTimeout AdaptiveTimeoutConfig
+
+	// ExecHedge, when MaxAttempts >= 2, hedges an individual Execute call:
+	// a second attempt at fn is launched if the first has not returned
+	// within the trigger delay, and the first successful attempt wins.
+	ExecHedge ExecHedgeConfig
+
+	// TenantOverrides replaces Rate and Timeout for the named tenant when a
+	// request carries a matching contract.Request.TenantID; a tenant absent
+	// from this map uses the source's shared, non-tenant-scoped policy.
+	TenantOverrides map[string]TenantOverride
+}
+
+// TenantOverride narrows a source's rate limit and timeout for a single
+// tenant, so e.g. a "free" tenant can be throttled harder and budgeted
+// tighter than an "enterprise" one sharing the same upstream source.
+type TenantOverride struct {
+	Rate    RateLimitConfig
+	Timeout time.Duration
+}
+
+// ExecHedgeConfig controls hedging a single SourcePolicy.Execute call. This
+// is distinct from the controller-level HedgeConfig above, which races whole
+// requests; ExecHedgeConfig races individual upstream calls within one.
+type ExecHedgeConfig struct {
+	// After is the fallback trigger delay used when no latency history is
+	// available yet, or when QuantileTrigger is zero.
+	After time.Duration
+	// MaxAttempts bounds the total number of concurrent attempts (including
+	// the primary); values below 2 disable hedging.
+	MaxAttempts int
+	// QuantileTrigger selects the quantile (0 < p <= 1) of recently observed
+	// latency to use as the hedge trigger once enough samples exist.
+	QuantileTrigger float64
+}
+
+// AdaptiveTimeoutConfig controls deriving the per-call deadline from
+// observed latency instead of a fixed SourceConfig.Timeout.
+type AdaptiveTimeoutConfig struct {
+	Enabled bool
+	// Quantile selects the latency percentile (0 < p <= 1) used as the
+	// deadline basis; defaults to 0.95 when Enabled and unset.
+	Quantile float64
+	// Window bounds how many recent latency samples feed the quantile
+	// estimate; <= 0 uses the reservoir's default size.
+	Window int
+	// Margin is added on top of the observed quantile as safety headroom.
+	Margin time.Duration
+	// MinTimeout and MaxTimeout clamp the resulting deadline.
+	MinTimeout time.Duration
+	MaxTimeout time.Duration
+}
+
+// HedgeConfig controls whether and how a second attempt is fired while the
+// first is still outstanding.
+type HedgeConfig struct {
+	Enabled bool
+	// MaxAttempts bounds the total number of concurrent attempts (including
+	// the primary); values below 2 disable hedging.
+	MaxAttempts int
+	// MinDelay is the fallback hedge trigger when no latency history is
+	// available yet, or when UsePercentile is zero.
+	MinDelay time.Duration
+	// UsePercentile selects the quantile (0 < p <= 1) of recent observed
+	// latency to use as the hedge trigger once enough samples exist.
+	UsePercentile float64
 }
 
 // SourcePolicy applies timeout, rate limiting, and circuit breakers per upstream.
 // mvp-5
 type SourcePolicy struct {
-	name    string
-	timeout time.Duration
-	rate    *TokenBucket
-	breaker *lightBreaker
+	name      string
+	timeout   time.Duration
+	rate      *TokenBucket
+	breaker   *CircuitBreaker
+	latencies *LatencyReservoir
+	adaptive  AdaptiveTimeoutConfig
+	execHedge ExecHedgeConfig
+
+	// tenant identifies the tenant this policy view was scoped to by
+	// Controller.Source, or "" for the shared, non-tenant-scoped policy.
+	tenant string
 }
 
-// NewSourcePolicy constructs a SourcePolicy with sane defaults.
+// NewSourcePolicy constructs a SourcePolicy with sane defaults. metrics may
+// be nil, in which case circuit state changes are not reported.
 // mvp-5
-func NewSourcePolicy(cfg SourceConfig) (*SourcePolicy, error) {
+func NewSourcePolicy(cfg SourceConfig, metrics *Metrics) (*SourcePolicy, error) {
 	if cfg.Name == "" {
 		return nil, errors.New("source name required")
 	}
@@ -61,50 +146,246 @@ func NewSourcePolicy(cfg SourceConfig) (*SourcePolicy, error) {
 		bucket = NewTokenBucket(cfg.Rate.Capacity, cfg.Rate.RefillTokens, cfg.Rate.RefillEvery)
 	}
 
-	breaker := newLightBreaker(cfg.Name, cfg.Circuit)
+	breaker := NewCircuitBreaker(cfg.Name, defaultCircuitBreakerConfig(cfg.Circuit), metrics)
+
+	adaptive := cfg.AdaptiveTimeout
+	if adaptive.Enabled && adaptive.Quantile <= 0 {
+		adaptive.Quantile = 0.95
+	}
 
 	return &SourcePolicy{
-		name:    cfg.Name,
-		timeout: cfg.Timeout,
-		rate:    bucket,
-		breaker: breaker,
+		name:      cfg.Name,
+		timeout:   cfg.Timeout,
+		rate:      bucket,
+		breaker:   breaker,
+		latencies: NewLatencyReservoir(adaptive.Window),
+		adaptive:  adaptive,
+		execHedge: cfg.ExecHedge,
 	}, nil
 }
 
-// Execute applies the policy controls to fn.
+// LatencyPercentile returns the p-th quantile of recently observed call
+// latencies, or zero if not enough history has accumulated yet.
+func (s *SourcePolicy) LatencyPercentile(p float64) time.Duration {
+	return s.latencies.Percentile(p)
+}
+
+// effectiveTimeout returns the static cfg.Timeout, or, when AdaptiveTimeout
+// is enabled and enough latency history has accumulated, a deadline derived
+// from the configured quantile plus margin, clamped to [MinTimeout,
+// MaxTimeout].
+func (s *SourcePolicy) effectiveTimeout() time.Duration {
+	if !s.adaptive.Enabled {
+		return s.timeout
+	}
+
+	observed := s.latencies.Percentile(s.adaptive.Quantile)
+	if observed <= 0 {
+		return s.timeout
+	}
+
+	timeout := observed + s.adaptive.Margin
+	if s.adaptive.MinTimeout > 0 && timeout < s.adaptive.MinTimeout {
+		timeout = s.adaptive.MinTimeout
+	}
+	if s.adaptive.MaxTimeout > 0 && timeout > s.adaptive.MaxTimeout {
+		timeout = s.adaptive.MaxTimeout
+	}
+
+	obs.SetEffectiveTimeout(s.name, timeout.Milliseconds())
+	return timeout
+}
+
+// callWithSpan invokes fn inside a child span named "source.<name>",
+// tagging it with the source name, observed latency, and classified error
+// (if any), so a single upstream call is visible end-to-end in an exported
+// trace alongside the server span that started it.
+func (s *SourcePolicy) callWithSpan(ctx context.Context, fn func(context.Context) error) (error, time.Duration) {
+	ctx, span := otel.Tracer(policyTracerName).Start(ctx, "source."+s.name, oteltrace.WithSpanKind(oteltrace.SpanKindClient))
+	defer span.End()
+
+	start := time.Now()
+	err := fn(ctx)
+	duration := time.Since(start)
+
+	span.SetAttributes(
+		attribute.String("source.name", s.name),
+		attribute.Int64("source.duration_ms", duration.Milliseconds()),
+	)
+	if err != nil {
+		class := classifyError(err)
+		span.SetAttributes(attribute.String("source.error_class", class))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, class)
+	} else {
+		span.SetStatus(codes.Ok, "")
+	}
+
+	obs.RecordSourceDuration(s.name, duration, span.SpanContext().TraceID().String())
+
+	return err, duration
+}
+
+// Execute applies the policy controls to fn. fn must be safe to invoke
+// concurrently: when ExecHedge.MaxAttempts >= 2, Execute may call fn more
+// than once for the same logical call, racing the attempts and canceling
+// the context passed to whichever attempt does not win.
 // mvp-5
 func (s *SourcePolicy) Execute(parent context.Context, fn func(context.Context) error) error {
 	if parent == nil {
 		parent = context.Background()
 	}
 
-	if !s.breaker.Allow() {
+	if s.execHedge.MaxAttempts >= 2 {
+		return s.executeHedged(parent, fn)
+	}
+	return s.executeOnce(parent, fn)
+}
+
+// executeOnce issues a single gated attempt against fn.
+func (s *SourcePolicy) executeOnce(parent context.Context, fn func(context.Context) error) error {
+	now := time.Now()
+	if !s.breaker.Allow(now) {
 		return ErrCircuitOpen
 	}
 
-	if s.rate != nil && !s.rate.Allow(time.Now()) {
+	if s.rate != nil && !s.rate.Allow(now) {
+		s.breaker.Record(now, false, classifyError(ErrRateLimited))
+		obs.RecordSourceError(s.name, "rate_limited", s.tenant)
+		obs.IncSourceErrorClass(s.name, "rate_limited")
 		return ErrRateLimited
 	}
 
-	ctx, cancel := context.WithTimeout(parent, s.timeout)
+	effectiveTimeout := s.effectiveTimeout()
+	ctx, cancel := context.WithTimeout(parent, effectiveTimeout)
 	defer cancel()
 
-	start := time.Now()
-	err := fn(ctx)
-	duration := time.Since(start)
+	err, duration := s.callWithSpan(ctx, fn)
 
 	if err != nil {
-		s.breaker.Fail()
-		obs.RecordSourceError(s.name, classifyError(err))
+		class := classifyError(err)
+		s.breaker.Record(time.Now(), false, class)
+		obs.RecordSourceError(s.name, class, s.tenant)
+		obs.IncSourceErrorClass(s.name, class)
 	} else {
-		s.breaker.Success()
+		s.breaker.Record(time.Now(), true)
 	}
 
-	obs.RecordSourceDuration(s.name, duration)
+	s.latencies.Observe(duration)
 	return err
 }
 
-// classifyError maps errors to metric codes.
+// execAttemptResult carries the outcome of one attempt launched by
+// executeHedged, tagged with its attempt number so the winner can be
+// distinguished for metrics.
+type execAttemptResult struct {
+	attempt int
+	err     error
+}
+
+// executeHedged races a primary call to fn against one or more speculative
+// retries fired while the primary is still outstanding, to bound tail
+// latency. Every attempt passes through the token bucket and circuit
+// breaker Allow gate independently and records its own latency/error, but
+// only the winning outcome (the first success, or the final failure once
+// every attempt has failed) drives the circuit breaker's Record exactly
+// once.
+func (s *SourcePolicy) executeHedged(parent context.Context, fn func(context.Context) error) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	results := make(chan execAttemptResult, s.execHedge.MaxAttempts)
+	launch := func(n int) {
+		go func() {
+			now := time.Now()
+			if !s.breaker.Allow(now) {
+				results <- execAttemptResult{attempt: n, err: ErrCircuitOpen}
+				return
+			}
+			if s.rate != nil && !s.rate.Allow(now) {
+				results <- execAttemptResult{attempt: n, err: ErrRateLimited}
+				return
+			}
+
+			attemptCtx, attemptCancel := context.WithTimeout(ctx, s.effectiveTimeout())
+			defer attemptCancel()
+
+			err, duration := s.callWithSpan(attemptCtx, fn)
+
+			if err != nil {
+				class := classifyError(err)
+				obs.RecordSourceError(s.name, class, s.tenant)
+				obs.IncSourceErrorClass(s.name, class)
+			}
+			s.latencies.Observe(duration)
+
+			results <- execAttemptResult{attempt: n, err: err}
+		}()
+	}
+
+	launch(1)
+	inFlight := 1
+	launched := 1
+	timer := time.NewTimer(s.hedgeDelay())
+	defer timer.Stop()
+
+	var lastErr error
+	for {
+		select {
+		case res := <-results:
+			inFlight--
+			if res.err == nil {
+				cancel()
+				outcome := "winner_primary"
+				if res.attempt > 1 {
+					outcome = "winner_hedge"
+				}
+				obs.IncSourceHedged(s.name, outcome)
+				s.breaker.Record(time.Now(), true)
+				return nil
+			}
+			lastErr = res.err
+			if inFlight == 0 && launched >= s.execHedge.MaxAttempts {
+				obs.IncSourceHedged(s.name, "both_failed")
+				s.breaker.Record(time.Now(), false, classifyError(lastErr))
+				return lastErr
+			}
+		case <-timer.C:
+			if launched < s.execHedge.MaxAttempts {
+				launched++
+				inFlight++
+				launch(launched)
+				timer.Reset(s.hedgeDelay())
+			}
+		case <-parent.Done():
+			return parent.Err()
+		}
+	}
+}
+
+// hedgeDelay computes the trigger delay for launching a speculative
+// attempt: the configured quantile of recently observed latency when
+// enough samples exist, falling back to ExecHedge.After, and finally to a
+// conservative default when neither is available.
+func (s *SourcePolicy) hedgeDelay() time.Duration {
+	delay := s.execHedge.After
+	if s.execHedge.QuantileTrigger > 0 {
+		if p := s.latencies.Percentile(s.execHedge.QuantileTrigger); p > 0 {
+			delay = p
+		}
+	}
+	if delay <= 0 {
+		delay = 20 * time.Millisecond
+	}
+	return delay
+}
+
+// classifyError maps errors to the metric/ClassThresholds codes documented
+// on CircuitBreakerConfig.ClassThresholds: "canceled", "timeout",
+// "rate_limited", "circuit_open", the gRPC status codes that name a class
+// (e.g. "unavailable", "resource_exhausted"), or the best-effort "5xx"/"4xx"
+// inferred from a plain HTTP-source error's message. Anything else falls
+// back to sanitize(err.Error())'s first-token heuristic.
 // mvp-5
 func classifyError(err error) string {
 	if err == nil {
@@ -116,9 +397,54 @@ func classifyError(err error) string {
 	if errors.Is(err, context.DeadlineExceeded) {
 		return "timeout"
 	}
+	if errors.Is(err, ErrRateLimited) {
+		return "rate_limited"
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return "circuit_open"
+	}
+	if class, ok := grpcErrorClass(grpcstatus.Code(err)); ok {
+		return class
+	}
+	if class, ok := httpErrorClass(err.Error()); ok {
+		return class
+	}
 	return sanitize(err.Error())
 }
 
+// grpcErrorClass maps a gRPC status code to a ClassThresholds class, for
+// sources that call out over gRPC rather than plain HTTP. Only codes worth
+// tuning a threshold for independently are named; codes.OK and codes.Unknown
+// (the code returned for a non-gRPC error) fall through to the caller's
+// other heuristics.
+func grpcErrorClass(code grpccodes.Code) (string, bool) {
+	switch code {
+	case grpccodes.Unavailable:
+		return "unavailable", true
+	case grpccodes.ResourceExhausted:
+		return "resource_exhausted", true
+	case grpccodes.DeadlineExceeded:
+		return "timeout", true
+	default:
+		return "", false
+	}
+}
+
+// httpErrorClass best-effort classifies a plain HTTP source's error message
+// into "5xx" or "4xx", matching the message shapes sources/qdrant.go's
+// execute produces ("server error: ..." for a retried 5xx, "qdrant error:
+// ..." for a terminal 4xx or exhausted-retry 5xx).
+func httpErrorClass(msg string) (string, bool) {
+	switch {
+	case strings.HasPrefix(msg, "server error:"):
+		return "5xx", true
+	case strings.HasPrefix(msg, "qdrant error:"):
+		return "4xx", true
+	default:
+		return "", false
+	}
+}
+
 func sanitize(msg string) string {
 	if msg == "" {
 		return "unknown"
@@ -133,100 +459,3 @@ func sanitize(msg string) string {
 	}
 	return msg
 }
-
-type breakerState string
-
-const (
-	stateClosed   breakerState = "closed"
-	stateOpen     breakerState = "open"
-	stateHalfOpen breakerState = "half-open"
-)
-
-type lightBreaker struct {
-	source            string
-	mu                sync.Mutex
-	state             breakerState
-	failures          int
-	successes         int
-	cfg               CircuitConfig
-	lastStateChange   time.Time
-}
-
-func newLightBreaker(source string, cfg CircuitConfig) *lightBreaker {
-	if cfg.FailureThreshold <= 0 {
-		cfg.FailureThreshold = 3
-	}
-	if cfg.HalfOpenSuccesses <= 0 {
-		cfg.HalfOpenSuccesses = 1
-	}
-	if cfg.Cooldown <= 0 {
-		cfg.Cooldown = 2 * time.Second
-	}
-	b := &lightBreaker{
-		source: source,
-		state:  stateClosed,
-		cfg:    cfg,
-	}
-	obs.SetCircuitState(source, string(stateClosed))
-	return b
-}
-
-func (b *lightBreaker) Allow() bool {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	switch b.state {
-	case stateOpen:
-		if time.Since(b.lastStateChange) >= b.cfg.Cooldown {
-			b.transition(stateHalfOpen)
-			return true
-		}
-		return false
-	default:
-		return true
-	}
-}
-
-func (b *lightBreaker) Fail() {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	b.failures++
-	switch b.state {
-	case stateHalfOpen:
-		b.transition(stateOpen)
-	case stateClosed:
-		if b.failures >= b.cfg.FailureThreshold {
-			b.transition(stateOpen)
-		}
-	}
-}
-
-func (b *lightBreaker) Success() {
-	b.mu.Lock()
-	defer b.mu.Unlock()
-
-	b.failures = 0
-	switch b.state {
-	case stateHalfOpen:
-		b.successes++
-		if b.successes >= b.cfg.HalfOpenSuccesses {
-			b.transition(stateClosed)
-		}
-	case stateOpen:
-		// ignored
-	default:
-		obs.SetCircuitState(b.source, string(stateClosed))
-	}
-}
-
-func (b *lightBreaker) transition(next breakerState) {
-	if b.state == next {
-		return
-	}
-	b.state = next
-	b.failures = 0
-	b.successes = 0
-	b.lastStateChange = time.Now()
-	obs.SetCircuitState(b.source, string(next))
-}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -90,7 +91,7 @@ func TestSourcePolicyCircuitOpensAfterFailures(t *testing.T) {
 		t.Fatalf("expected circuit open error, got %v", err)
 	}
 
-	time.Sleep(cfg.Cooldown + 20*time.Millisecond)
+	time.Sleep(cfg.Circuit.Cooldown + 20*time.Millisecond)
 
 	fake.SetResponses(testutil.FakeResponse{Status: http.StatusOK})
 
@@ -99,3 +100,137 @@ func TestSourcePolicyCircuitOpensAfterFailures(t *testing.T) {
 	}
 }
 
+func TestClassifyErrorProducesClassThresholdsClasses(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{ErrRateLimited, "rate_limited"},
+		{ErrCircuitOpen, "circuit_open"},
+		{context.Canceled, "canceled"},
+		{context.DeadlineExceeded, "timeout"},
+		{fmt.Errorf("server error: boom"), "5xx"},
+		{fmt.Errorf("qdrant error: bad request"), "4xx"},
+	}
+	for _, tc := range cases {
+		if got := classifyError(tc.err); got != tc.want {
+			t.Errorf("classifyError(%v) = %q, want %q", tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestSourcePolicyAdaptiveTimeoutTracksObservedLatency(t *testing.T) {
+	cfg := SourceConfig{
+		Name:    "fake",
+		Timeout: 500 * time.Millisecond,
+		AdaptiveTimeout: AdaptiveTimeoutConfig{
+			Enabled:    true,
+			Quantile:   0.95,
+			Margin:     20 * time.Millisecond,
+			MinTimeout: 10 * time.Millisecond,
+			MaxTimeout: 200 * time.Millisecond,
+		},
+	}
+
+	p, err := NewSourcePolicy(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	fast := func(ctx context.Context) error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	}
+	for i := 0; i < 10; i++ {
+		if err := p.Execute(ctx, fast); err != nil {
+			t.Fatalf("unexpected error on call %d: %v", i, err)
+		}
+	}
+
+	got := p.effectiveTimeout()
+	if got >= cfg.Timeout {
+		t.Fatalf("expected adaptive timeout (%v) to drop below the static fallback (%v) after observing fast calls", got, cfg.Timeout)
+	}
+	if got < cfg.AdaptiveTimeout.MinTimeout || got > cfg.AdaptiveTimeout.MaxTimeout {
+		t.Fatalf("expected adaptive timeout %v to stay within [%v, %v]", got, cfg.AdaptiveTimeout.MinTimeout, cfg.AdaptiveTimeout.MaxTimeout)
+	}
+}
+
+func TestExecuteHedgeWinsOnSlowPrimary(t *testing.T) {
+	cfg := SourceConfig{
+		Name:    "fake",
+		Timeout: time.Second,
+		ExecHedge: ExecHedgeConfig{
+			After:       20 * time.Millisecond,
+			MaxAttempts: 2,
+		},
+	}
+
+	p, err := NewSourcePolicy(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var attempts int32
+	fn := func(ctx context.Context) error {
+		n := atomic.AddInt32(&attempts, 1)
+		if n == 1 {
+			// The primary attempt: block until hedged away, so it never wins.
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	}
+
+	start := time.Now()
+	if err := p.Execute(context.Background(), fn); err != nil {
+		t.Fatalf("expected the hedged attempt to succeed, got %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed >= 150*time.Millisecond {
+		t.Fatalf("expected the hedge to win well before the primary's block resolves, took %v", elapsed)
+	}
+	if atomic.LoadInt32(&attempts) < 2 {
+		t.Fatalf("expected a second, hedged attempt to have launched")
+	}
+}
+
+func TestSourcePolicyRecordsRateLimitedOnBreaker(t *testing.T) {
+	cfg := SourceConfig{
+		Name:    "fake",
+		Timeout: 100 * time.Millisecond,
+		Rate: RateLimitConfig{
+			Capacity:     1,
+			RefillTokens: 1,
+			RefillEvery:  time.Hour,
+		},
+		Circuit: CircuitBreakerConfig{
+			Window:               time.Minute,
+			FailureRateThreshold: 0.5,
+			MinSamples:           1,
+			ClassThresholds:      map[string]float64{"rate_limited": 0},
+		},
+	}
+
+	p, err := NewSourcePolicy(cfg, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx := context.Background()
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := p.Execute(ctx, noop); err != nil {
+		t.Fatalf("expected first call to succeed, got %v", err)
+	}
+	if err := p.Execute(ctx, noop); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected second call to be rate limited, got %v", err)
+	}
+
+	if err := p.Execute(ctx, noop); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected the rate-limited rejection to have tripped the breaker, got %v", err)
+	}
+}
+
@@ -0,0 +1,79 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TenantConfigFile is the on-disk YAML shape for per-source tenant
+// overrides, reloadable without a process restart via Controller.Reload:
+//
+//	sources:
+//	  qdrant:
+//	    tenants:
+//	      free:
+//	        rate: {capacity: 5, refill_tokens: 5, refill_every_ms: 1000}
+//	        budget_ms: 200
+//	      enterprise:
+//	        rate: {capacity: 100, refill_tokens: 100, refill_every_ms: 1000}
+//	        budget_ms: 1500
+type TenantConfigFile struct {
+	Sources map[string]struct {
+		Tenants map[string]struct {
+			Rate struct {
+				Capacity      int `yaml:"capacity"`
+				RefillTokens  int `yaml:"refill_tokens"`
+				RefillEveryMs int `yaml:"refill_every_ms"`
+			} `yaml:"rate"`
+			BudgetMs int `yaml:"budget_ms"`
+		} `yaml:"tenants"`
+	} `yaml:"sources"`
+}
+
+// LoadTenantOverrides reads a TenantConfigFile from path and layers it on
+// top of base, returning a new ControllerConfig whose matching sources have
+// TenantOverrides replaced. Sources named in base but absent from the file
+// keep their existing TenantOverrides; sources named in the file but not in
+// base are ignored, since ControllerConfig.Sources is the source of truth
+// for which upstreams exist.
+func LoadTenantOverrides(path string, base ControllerConfig) (ControllerConfig, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return ControllerConfig{}, fmt.Errorf("read tenant config: %w", err)
+	}
+
+	var file TenantConfigFile
+	if err := yaml.Unmarshal(raw, &file); err != nil {
+		return ControllerConfig{}, fmt.Errorf("parse tenant config: %w", err)
+	}
+
+	cfg := base
+	cfg.Sources = make([]SourceConfig, len(base.Sources))
+	copy(cfg.Sources, base.Sources)
+
+	for i, sc := range cfg.Sources {
+		src, ok := file.Sources[sc.Name]
+		if !ok {
+			continue
+		}
+
+		overrides := make(map[string]TenantOverride, len(src.Tenants))
+		for tenantID, t := range src.Tenants {
+			overrides[tenantID] = TenantOverride{
+				Rate: RateLimitConfig{
+					Capacity:     t.Rate.Capacity,
+					RefillTokens: t.Rate.RefillTokens,
+					RefillEvery:  time.Duration(t.Rate.RefillEveryMs) * time.Millisecond,
+				},
+				Timeout: time.Duration(t.BudgetMs) * time.Millisecond,
+			}
+		}
+		sc.TenantOverrides = overrides
+		cfg.Sources[i] = sc
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,91 @@
+package sources
+
+// mvp-5
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/searchforge/retrieval_proxy/policy"
+)
+
+// SourceSpec declares one upstream in a SOURCES_CONFIG file: its name,
+// backend type, connection details, and the policy (timeout, rate limit,
+// circuit breaker) that should gate calls to it.
+type SourceSpec struct {
+	Name       string              `json:"name"`
+	Type       string              `json:"type"` // "qdrant" or "grpc"
+	Collection string              `json:"collection"`
+	Qdrant     *QdrantSpec         `json:"qdrant,omitempty"`
+	GRPC       *GRPCSpec           `json:"grpc,omitempty"`
+	Policy     policy.SourceConfig `json:"policy"`
+}
+
+// QdrantSpec configures an in-process QdrantSource-backed plugin.
+type QdrantSpec struct {
+	URL          string `json:"url"`
+	RetryMax     int    `json:"retry_max"`
+	HedgeAfterMs int    `json:"hedge_after_ms"`
+	HedgeMax     int    `json:"hedge_max"`
+}
+
+// GRPCSpec configures an out-of-process plugin reached over gRPC, per the
+// wire contract in sourcepb/source.proto.
+type GRPCSpec struct {
+	Target string `json:"target"`
+}
+
+// LoadSourcesConfig reads a JSON file enumerating the upstream sources the
+// proxy should fan out to, as pointed to by the SOURCES_CONFIG env var.
+func LoadSourcesConfig(path string) ([]SourceSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading sources config: %w", err)
+	}
+
+	var specs []SourceSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		return nil, fmt.Errorf("parsing sources config: %w", err)
+	}
+	for i, spec := range specs {
+		if spec.Name == "" {
+			return nil, fmt.Errorf("sources config[%d]: name required", i)
+		}
+		switch spec.Type {
+		case "qdrant":
+			if spec.Qdrant == nil || spec.Qdrant.URL == "" {
+				return nil, fmt.Errorf("sources config[%d] %q: qdrant.url required", i, spec.Name)
+			}
+		case "grpc":
+			if spec.GRPC == nil || spec.GRPC.Target == "" {
+				return nil, fmt.Errorf("sources config[%d] %q: grpc.target required", i, spec.Name)
+			}
+		default:
+			return nil, fmt.Errorf("sources config[%d] %q: unknown type %q", i, spec.Name, spec.Type)
+		}
+	}
+	return specs, nil
+}
+
+// BuildPlugin constructs the concrete Plugin described by spec.
+func (spec SourceSpec) BuildPlugin(client HTTPClient) (Plugin, error) {
+	switch spec.Type {
+	case "qdrant":
+		retryMax := spec.Qdrant.RetryMax
+		if retryMax <= 0 {
+			retryMax = defaultRetryMax
+		}
+		hedgeAfter := time.Duration(spec.Qdrant.HedgeAfterMs) * time.Millisecond
+		source, err := NewQdrantSource(spec.Qdrant.URL, client, retryMax, hedgeAfter, spec.Qdrant.HedgeMax)
+		if err != nil {
+			return nil, err
+		}
+		return NewQdrantPlugin(source, spec.Collection), nil
+	case "grpc":
+		return NewGRPCPlugin(spec.GRPC.Target)
+	default:
+		return nil, fmt.Errorf("unknown source type %q", spec.Type)
+	}
+}
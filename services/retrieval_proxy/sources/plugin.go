@@ -0,0 +1,56 @@
+package sources
+
+// mvp-5
+
+import "context"
+
+// Plugin is the interface every pluggable upstream retrieval backend must
+// implement, whether it runs in-process (QdrantPlugin wrapping a
+// QdrantSource) or out-of-process behind a connection to a separate binary
+// (GRPCPlugin). The controller fans a query out to every registered Plugin
+// and merges their SourceResults with fuse.RRFCombine (or
+// fuse.WeightedCombine when a request carries per-source weights), so a Plugin only
+// needs to know how to talk to its own backend.
+type Plugin interface {
+	// Search executes q against the plugin's backend.
+	Search(ctx context.Context, q Query) (Result, error)
+	// HealthCheck reports whether the plugin is ready to serve Search calls.
+	HealthCheck(ctx context.Context) error
+	// Close releases any resources (connections, subprocesses) held by the
+	// plugin. It is safe to call once a plugin is no longer registered.
+	Close() error
+}
+
+// QdrantPlugin adapts a QdrantSource, which searches multiple queries
+// concurrently in a single call, to the one-query-at-a-time Plugin
+// interface.
+type QdrantPlugin struct {
+	source     *QdrantSource
+	collection string
+}
+
+// NewQdrantPlugin wraps source so it can be registered under the Plugin
+// interface, defaulting queries to collection when a Query leaves it unset.
+func NewQdrantPlugin(source *QdrantSource, collection string) *QdrantPlugin {
+	return &QdrantPlugin{source: source, collection: collection}
+}
+
+// Search implements Plugin.
+func (p *QdrantPlugin) Search(ctx context.Context, q Query) (Result, error) {
+	if q.Collection == "" {
+		q.Collection = p.collection
+	}
+	result := p.source.Search(ctx, []Query{q})
+	return result, result.Err
+}
+
+// HealthCheck implements Plugin.
+func (p *QdrantPlugin) HealthCheck(ctx context.Context) error {
+	return p.source.Ping(ctx)
+}
+
+// Close implements Plugin. QdrantSource holds no closable resources of its
+// own (its HTTPClient outlives individual plugins), so this is a no-op.
+func (p *QdrantPlugin) Close() error {
+	return nil
+}
@@ -13,6 +13,11 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/searchforge/retrieval_proxy/obs"
 )
 
 const (
@@ -22,6 +27,10 @@ const (
 	maxBackoff       = 2 * time.Second
 	qdrantSearchPath = "/collections/%s/points/search"
 	contentTypeJSON  = "application/json"
+
+	// defaultHedgeMax is the default attempt count. At one attempt hedging is
+	// disabled: only the primary attempt ever fires.
+	defaultHedgeMax = 1
 )
 
 // HTTPClient represents a minimal http client.
@@ -34,6 +43,13 @@ type QdrantSource struct {
 	baseURL  string
 	client   HTTPClient
 	retryMax int
+
+	// hedgeAfter is how long a query's primary attempt is given before a
+	// speculative second attempt fires; <= 0 disables hedging.
+	hedgeAfter time.Duration
+	// hedgeMax bounds the total number of concurrent attempts (including
+	// the primary) per query; values below 2 disable hedging.
+	hedgeMax int
 }
 
 // Query encapsulates a search request for a single Qdrant collection.
@@ -51,8 +67,10 @@ type Result struct {
 	Err    error
 }
 
-// NewQdrantSource creates a Qdrant source client.
-func NewQdrantSource(baseURL string, client HTTPClient, retryMax int) (*QdrantSource, error) {
+// NewQdrantSource creates a Qdrant source client. hedgeAfter <= 0 or
+// hedgeMax < 2 disables hedging, so existing callers passing the zero value
+// for both see no behavior change.
+func NewQdrantSource(baseURL string, client HTTPClient, retryMax int, hedgeAfter time.Duration, hedgeMax int) (*QdrantSource, error) {
 	if baseURL == "" {
 		return nil, fmt.Errorf("qdrant baseURL required")
 	}
@@ -65,11 +83,16 @@ func NewQdrantSource(baseURL string, client HTTPClient, retryMax int) (*QdrantSo
 	if retryMax < 0 {
 		retryMax = defaultRetryMax
 	}
+	if hedgeMax < defaultHedgeMax {
+		hedgeMax = defaultHedgeMax
+	}
 
 	return &QdrantSource{
-		baseURL:  strings.TrimRight(baseURL, "/"),
-		client:   client,
-		retryMax: retryMax,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		client:     client,
+		retryMax:   retryMax,
+		hedgeAfter: hedgeAfter,
+		hedgeMax:   hedgeMax,
 	}, nil
 }
 
@@ -82,12 +105,14 @@ func NewQdrantSourceFromEnv() (*QdrantSource, error) {
 
 	timeout := parseDurationFromEnv("TIMEOUT_MS", defaultTimeout)
 	retryMax := parseIntFromEnv("RETRY_MAX", defaultRetryMax)
+	hedgeAfter := parseDurationFromEnv("HEDGE_AFTER_MS", 0)
+	hedgeMax := parseIntFromEnv("HEDGE_MAX", defaultHedgeMax)
 
 	httpClient := &http.Client{
 		Timeout: timeout,
 	}
 
-	return NewQdrantSource(baseURL, httpClient, retryMax)
+	return NewQdrantSource(baseURL, httpClient, retryMax, hedgeAfter, hedgeMax)
 }
 
 // Search executes the provided queries concurrently.
@@ -116,7 +141,7 @@ func (s *QdrantSource) Search(ctx context.Context, queries []Query) Result {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			item, code, err := s.execute(ctx, query)
+			item, code, err := s.executeHedged(ctx, query)
 			if err != nil {
 				errOnce.Do(func() {
 					result.Err = err
@@ -143,6 +168,80 @@ func (s *QdrantSource) Search(ctx context.Context, queries []Query) Result {
 	return result
 }
 
+// hedgeAttemptResult carries the outcome of one attempt launched by
+// executeHedged, tagged with its attempt number so the winner can be
+// recorded for metrics.
+type hedgeAttemptResult struct {
+	attempt int
+	item    json.RawMessage
+	code    int
+	err     error
+}
+
+// executeHedged races a primary call to execute against one or more
+// speculative retries fired every hedgeAfter while the primary (and any
+// prior speculative attempt) is still outstanding, taking whichever attempt
+// returns first without error and cancelling the rest. This complements the
+// retry loop inside execute, which only fires a new attempt after the
+// previous one has already failed. Hedging is skipped entirely when
+// hedgeAfter or hedgeMax disables it, so this is a no-op wrapper around
+// execute by default.
+func (s *QdrantSource) executeHedged(ctx context.Context, query Query) (json.RawMessage, int, error) {
+	if s.hedgeAfter <= 0 || s.hedgeMax < 2 {
+		return s.execute(ctx, query)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeAttemptResult, s.hedgeMax)
+	launch := func(attempt int) {
+		go func() {
+			item, code, err := s.execute(ctx, query)
+			results <- hedgeAttemptResult{attempt: attempt, item: item, code: code, err: err}
+		}()
+	}
+
+	launch(1)
+	launched := 1
+	timer := time.NewTimer(s.hedgeAfter)
+	defer timer.Stop()
+
+	var (
+		firstErr     error
+		firstErrCode int
+		failures     int
+	)
+
+	for {
+		select {
+		case res := <-results:
+			if res.err == nil {
+				obs.IncSourceHedge(query.Collection, strconv.Itoa(res.attempt))
+				cancel()
+				return res.item, res.code, nil
+			}
+			failures++
+			if firstErr == nil {
+				firstErr = res.err
+				firstErrCode = res.code
+			}
+			if failures == launched {
+				obs.IncSourceHedge(query.Collection, "both_failed")
+				return nil, firstErrCode, firstErr
+			}
+		case <-timer.C:
+			if launched < s.hedgeMax {
+				launched++
+				launch(launched)
+				timer.Reset(s.hedgeAfter)
+			}
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}
+}
+
 func (s *QdrantSource) execute(ctx context.Context, query Query) (json.RawMessage, int, error) {
 	if query.Collection == "" {
 		return nil, 0, fmt.Errorf("collection required")
@@ -171,6 +270,7 @@ func (s *QdrantSource) execute(ctx context.Context, query Query) (json.RawMessag
 		}
 		req.Header.Set("Content-Type", contentTypeJSON)
 		req.Header.Set("Accept", contentTypeJSON)
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
 
 		for k, values := range query.Headers {
 			for _, v := range values {
@@ -221,6 +321,23 @@ func (s *QdrantSource) String() string {
 	return fmt.Sprintf("qdrant_source{base=%s,retry_max=%d}", s.baseURL, s.retryMax)
 }
 
+// Ping performs a lightweight readiness probe against the Qdrant instance.
+func (s *QdrantSource) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/", nil)
+	if err != nil {
+		return fmt.Errorf("create ping request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("qdrant ping: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("qdrant ping: status %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func parseDurationFromEnv(key string, fallback time.Duration) time.Duration {
 	value := strings.TrimSpace(os.Getenv(key))
 	if value == "" {
@@ -267,4 +384,3 @@ func sleepWithContext(ctx context.Context, d time.Duration) bool {
 		return true
 	}
 }
-
@@ -0,0 +1,70 @@
+package sources
+
+// mvp-5
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Registry holds named Plugin instances so the controller can fan a query
+// out to every configured backend without knowing their concrete types.
+type Registry struct {
+	mu      sync.RWMutex
+	plugins map[string]Plugin
+	order   []string
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{plugins: make(map[string]Plugin)}
+}
+
+// Register adds p under name. It returns an error if name is empty or
+// already registered.
+func (r *Registry) Register(name string, p Plugin) error {
+	if name == "" {
+		return fmt.Errorf("source name required")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.plugins[name]; exists {
+		return fmt.Errorf("source %q already registered", name)
+	}
+	r.plugins[name] = p
+	r.order = append(r.order, name)
+	return nil
+}
+
+// Get returns the plugin registered under name, if any.
+func (r *Registry) Get(name string) (Plugin, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.plugins[name]
+	return p, ok
+}
+
+// Names returns the registered source names in registration order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, len(r.order))
+	copy(names, r.order)
+	return names
+}
+
+// Close closes every registered plugin, returning the first error
+// encountered (if any) after attempting them all.
+func (r *Registry) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var firstErr error
+	for _, name := range r.order {
+		if err := r.plugins[name].Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("closing source %q: %w", name, err)
+		}
+	}
+	return firstErr
+}